@@ -126,6 +126,31 @@ func BenchmarkFieldTypes(b *testing.B) {
 			InfoS(WithDuration("elapsed", testDuration))
 		}
 	})
+
+	b.Run("Ints", func(b *testing.B) {
+		ids := []int{101, 102, 103, 104}
+		for b.Loop() {
+			InfoS(WithInts("ids", ids))
+		}
+	})
+
+	b.Run("Group", func(b *testing.B) {
+		for b.Loop() {
+			InfoS(WithGroup("http", WithString("method", "GET"), WithInt("status", 200)))
+		}
+	})
+}
+
+func BenchmarkWith(b *testing.B) {
+	logger := New(InfoLevel, &discardWriter{}, OptionIncludeFileInfo(false)).With(
+		WithString("request_id", "4f9a2c"),
+		WithString("tenant", "acme"),
+	)
+	b.ResetTimer()
+
+	for b.Loop() {
+		logger.InfoS(WithString("action", "login"), WithInt("count", 42))
+	}
 }
 
 func BenchmarkFiltered(b *testing.B) {
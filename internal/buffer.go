@@ -15,8 +15,8 @@ var (
 	largeBufPool  = sync.Pool{New: func() any { buf := make([]byte, 0, largeBufSize); return &buf }}
 )
 
-// getBuf returns a buffer from the appropriate pool based on the requested size
-func getBuf(size int) *[]byte {
+// GetBuf returns a buffer from the appropriate pool based on the requested size
+func GetBuf(size int) *[]byte {
 	switch {
 	case size <= smallBufSize:
 		return smallBufPool.Get().(*[]byte)
@@ -27,8 +27,8 @@ func getBuf(size int) *[]byte {
 	}
 }
 
-// putBuf returns a buffer to the appropriate pool
-func putBuf(buf *[]byte) {
+// PutBuf returns a buffer to the appropriate pool
+func PutBuf(buf *[]byte) {
 	if cap(*buf) > maxBufSize {
 		return
 	}
@@ -1,10 +1,16 @@
 package internal
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"math"
 	"runtime"
 	"strconv"
 	"time"
+	"unicode/utf8"
+	"unsafe"
 )
 
 // getCaller returns the filename and line number of the caller
@@ -21,6 +27,25 @@ func getCaller(skip int) (filename string, lineNumber int) {
 	return file, line
 }
 
+// Caller returns the filename and line number skip frames above the function calling Caller
+func Caller(skip int) (filename string, lineNumber int) {
+	return getCaller(skip + 2)
+}
+
+// CallerFromPC returns the filename and line number of the given program counter
+func CallerFromPC(pc uintptr) (filename string, lineNumber int) {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	if frame.File == "" {
+		return "???", frame.Line
+	}
+	for i := len(frame.File) - 1; i > 0; i-- {
+		if frame.File[i] == '/' {
+			return frame.File[i+1:], frame.Line
+		}
+	}
+	return frame.File, frame.Line
+}
+
 // Sprint formats values similar to fmt.Sprint but optimized for logging
 func Sprint(v ...any) string {
 	if len(v) == 0 {
@@ -66,12 +91,21 @@ func Sprintf(format string, args ...any) string {
 
 // Text formatting functions
 
-// AppendTextHeader formats and appends a text log header to the buffer
-func AppendTextHeader(buf []byte, timestamp, levelStr, file string, line int, includeFileInfo bool) []byte {
-	buf = append(buf, timestamp...)
-	buf = append(buf, " ["...)
+// AppendTextHeader formats and appends a text log header to the buffer. An
+// empty timestamp is left out
+func AppendTextHeader(buf []byte, timestamp []byte, levelStr, name, file string, line int, includeFileInfo bool) []byte {
+	if len(timestamp) > 0 {
+		buf = append(buf, timestamp...)
+		buf = append(buf, ' ')
+	}
+	buf = append(buf, '[')
 	buf = append(buf, levelStr...)
 	buf = append(buf, ']')
+	if name != "" {
+		buf = append(buf, " ["...)
+		buf = append(buf, name...)
+		buf = append(buf, ']')
+	}
 	if includeFileInfo {
 		buf = append(buf, " ("...)
 		buf = append(buf, file...)
@@ -83,19 +117,365 @@ func AppendTextHeader(buf []byte, timestamp, levelStr, file string, line int, in
 	return buf
 }
 
+// AppendTextKey appends " prefixkey=" to the buffer
+func AppendTextKey(buf []byte, prefix, key string) []byte {
+	buf = append(buf, ' ')
+	buf = append(buf, prefix...)
+	buf = append(buf, key...)
+	return append(buf, '=')
+}
+
+// AppendTypedTextValue appends a typed field value in text form to the buffer
+func AppendTypedTextValue(buf []byte, field *Data) []byte {
+	switch field.Type {
+	case StringType, ErrorType:
+		return AppendTextString(buf, field.String)
+	case IntType:
+		return strconv.AppendInt(buf, field.Integer, 10)
+	case FloatType:
+		return strconv.AppendFloat(buf, field.Float, 'f', -1, 64)
+	case BoolType:
+		return strconv.AppendBool(buf, field.Bool)
+	case DurationType:
+		return append(buf, time.Duration(field.Integer).String()...)
+	case TimeType:
+		if t, ok := field.Interface.(time.Time); ok {
+			return t.AppendFormat(buf, time.RFC3339Nano)
+		}
+		return append(buf, "<nil>"...)
+	case UintType:
+		return strconv.AppendUint(buf, uint64(field.Integer), 10)
+	case Float32Type:
+		return strconv.AppendFloat(buf, field.Float, 'f', -1, 32)
+	case BytesType:
+		return appendEncodedBytes(buf, field, AppendTextString)
+	case StringerType:
+		return AppendTextString(buf, StringerValue(field.Interface))
+	case ComplexType:
+		return AppendComplex(buf, field)
+	case NilType:
+		return append(buf, "<nil>"...)
+	default:
+		return AppendTextValue(buf, field.Interface)
+	}
+}
+
+// AppendTextValue appends any value in text form to the buffer
+func AppendTextValue(buf []byte, v any) []byte {
+	switch val := v.(type) {
+	case string:
+		return AppendTextString(buf, val)
+	case error:
+		return AppendTextString(buf, val.Error())
+	case int, int32, int64, uint, uint32, uint64, float32, float64, bool, nil:
+		return appendAny(buf, val)
+	default:
+		return AppendJSONValue(buf, val)
+	}
+}
+
+// AppendTextString appends a string to the buffer, quoting it only when it
+// would otherwise be ambiguous in a key=value listing
+func AppendTextString(buf []byte, s string) []byte {
+	if s == "" {
+		return append(buf, `""`...)
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+			return strconv.AppendQuote(buf, s)
+		}
+	}
+	return append(buf, s...)
+}
+
+// Console formatting functions
+
+// ANSI escape sequences used by the console format
+const (
+	ColorReset   = "\x1b[0m"
+	ColorDim     = "\x1b[2m"
+	ColorRed     = "\x1b[31m"
+	ColorYellow  = "\x1b[33m"
+	ColorBlue    = "\x1b[34m"
+	ColorMagenta = "\x1b[35m"
+)
+
+// consoleLevelWidth is the width levels are padded to in the console format
+const consoleLevelWidth = 5
+
+// AppendConsoleHeader formats and appends a console log header to the buffer.
+// The level is written in levelColor and the other elements are dimmed,
+// unless levelColor is empty. An empty timestamp is left out
+func AppendConsoleHeader(buf []byte, timestamp []byte, levelStr, levelColor, name, file string, line int, includeFileInfo bool) []byte {
+	if len(timestamp) > 0 {
+		if levelColor != "" {
+			buf = append(buf, ColorDim...)
+			buf = append(buf, timestamp...)
+			buf = append(buf, ColorReset...)
+		} else {
+			buf = append(buf, timestamp...)
+		}
+		buf = append(buf, ' ')
+	}
+	buf = appendColored(buf, levelColor, levelColor != "", levelStr)
+	for i := len(levelStr); i < consoleLevelWidth; i++ {
+		buf = append(buf, ' ')
+	}
+	if name != "" || includeFileInfo {
+		buf = append(buf, ' ')
+		if levelColor != "" {
+			buf = append(buf, ColorDim...)
+		}
+		if name != "" {
+			buf = append(buf, '[')
+			buf = append(buf, name...)
+			buf = append(buf, ']')
+		}
+		if includeFileInfo {
+			if name != "" {
+				buf = append(buf, ' ')
+			}
+			buf = append(buf, file...)
+			buf = append(buf, ':')
+			buf = strconv.AppendInt(buf, int64(line), 10)
+		}
+		if levelColor != "" {
+			buf = append(buf, ColorReset...)
+		}
+	}
+	buf = append(buf, " ▶ "...)
+	return buf
+}
+
+// AppendConsoleMessage appends the message to the buffer, padded to width
+// columns when fields follow it so that their pairs line up
+func AppendConsoleMessage(buf []byte, msg string, width int, padded bool) []byte {
+	buf = append(buf, msg...)
+	if padded && msg != "" {
+		for i := utf8.RuneCountInString(msg); i < width; i++ {
+			buf = append(buf, ' ')
+		}
+	}
+	return buf
+}
+
+// appendColored appends s to the buffer, wrapped in color if colored is set
+func appendColored(buf []byte, color string, colored bool, s string) []byte {
+	if !colored {
+		return append(buf, s...)
+	}
+	buf = append(buf, color...)
+	buf = append(buf, s...)
+	return append(buf, ColorReset...)
+}
+
+// Logfmt formatting functions
+
+// HeaderKeys holds the keys of the header elements rendered for one format
+// along with their separators, such as `,"level":` in JSON or " level=" in
+// logfmt. Elements with an empty key are left out
+type HeaderKeys struct {
+	Time       string
+	Level      string
+	Name       string
+	Caller     string
+	Message    string
+	Stacktrace string
+}
+
+// renderHeaderKeys renders each non-empty key with render
+func renderHeaderKeys(keys HeaderKeys, render func(key string) string) HeaderKeys {
+	for _, key := range []*string{&keys.Time, &keys.Level, &keys.Name, &keys.Caller, &keys.Message, &keys.Stacktrace} {
+		if *key != "" {
+			*key = render(*key)
+		}
+	}
+	return keys
+}
+
+// AppendLogfmtHeaderKey appends a rendered logfmt key, without its leading
+// space if it is the first element of the line starting at start
+func AppendLogfmtHeaderKey(buf []byte, start int, key string) []byte {
+	if len(buf) == start {
+		return append(buf, key[1:]...)
+	}
+	return append(buf, key...)
+}
+
+// LogfmtHeaderKeys renders the keys of the logfmt header
+func LogfmtHeaderKeys(keys HeaderKeys) HeaderKeys {
+	return renderHeaderKeys(keys, func(key string) string {
+		return string(AppendLogfmtKey(nil, "", key))
+	})
+}
+
+// AppendLogfmtHeader formats and appends a logfmt header to the buffer. The
+// timestamp is an encoded logfmt value; an empty one is left out
+func AppendLogfmtHeader(buf []byte, keys *HeaderKeys, timestamp []byte, levelStr, name, file string, line int, includeFileInfo bool) []byte {
+	start := len(buf)
+	if len(timestamp) > 0 && keys.Time != "" {
+		buf = AppendLogfmtHeaderKey(buf, start, keys.Time)
+		buf = append(buf, timestamp...)
+	}
+	if keys.Level != "" {
+		buf = AppendLogfmtHeaderKey(buf, start, keys.Level)
+		for i := 0; i < len(levelStr); i++ {
+			c := levelStr[i]
+			if 'A' <= c && c <= 'Z' {
+				c += 'a' - 'A'
+			}
+			buf = append(buf, c)
+		}
+	}
+	if name != "" && keys.Name != "" {
+		buf = AppendLogfmtHeaderKey(buf, start, keys.Name)
+		buf = AppendLogfmtString(buf, name)
+	}
+	if includeFileInfo && keys.Caller != "" {
+		buf = AppendLogfmtHeaderKey(buf, start, keys.Caller)
+		buf = append(buf, file...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(line), 10)
+	}
+	return buf
+}
+
+// AppendLogfmtKey appends " prefixkey=" to the buffer, replacing characters
+// that are not allowed in logfmt keys with underscores
+func AppendLogfmtKey(buf []byte, prefix, key string) []byte {
+	buf = append(buf, ' ')
+	if prefix == "" && key == "" {
+		buf = append(buf, '_')
+	}
+	buf = appendLogfmtKeyPart(buf, prefix)
+	buf = appendLogfmtKeyPart(buf, key)
+	return append(buf, '=')
+}
+
+// appendLogfmtKeyPart appends s to the buffer with the characters that are
+// not allowed in logfmt keys replaced by underscores
+func appendLogfmtKeyPart(buf []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+			buf = append(buf, '_')
+		} else {
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+// AppendLogfmtString appends a string to the buffer, quoting and escaping it
+// only when it contains spaces, '=', '"', control characters or invalid UTF-8
+func AppendLogfmtString(buf []byte, s string) []byte {
+	if s == "" {
+		return append(buf, `""`...)
+	}
+	checkedUTF8 := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+			return strconv.AppendQuote(buf, s)
+		}
+		if c >= utf8.RuneSelf && !checkedUTF8 {
+			if !utf8.ValidString(s[i:]) {
+				return strconv.AppendQuote(buf, s)
+			}
+			checkedUTF8 = true
+		}
+	}
+	return append(buf, s...)
+}
+
+// AppendTypedLogfmtValue appends a typed field value in logfmt form to the buffer
+func AppendTypedLogfmtValue(buf []byte, field *Data) []byte {
+	switch field.Type {
+	case StringType, ErrorType:
+		return AppendLogfmtString(buf, field.String)
+	case IntType:
+		return strconv.AppendInt(buf, field.Integer, 10)
+	case FloatType:
+		return strconv.AppendFloat(buf, field.Float, 'f', -1, 64)
+	case BoolType:
+		return strconv.AppendBool(buf, field.Bool)
+	case DurationType:
+		return append(buf, time.Duration(field.Integer).String()...)
+	case TimeType:
+		if t, ok := field.Interface.(time.Time); ok {
+			return t.AppendFormat(buf, time.RFC3339Nano)
+		}
+		return append(buf, "null"...)
+	case UintType:
+		return strconv.AppendUint(buf, uint64(field.Integer), 10)
+	case Float32Type:
+		return strconv.AppendFloat(buf, field.Float, 'f', -1, 32)
+	case BytesType:
+		return appendEncodedBytes(buf, field, AppendLogfmtString)
+	case StringerType:
+		return AppendLogfmtString(buf, StringerValue(field.Interface))
+	case ComplexType:
+		return AppendComplex(buf, field)
+	case NilType:
+		return append(buf, "null"...)
+	default:
+		return AppendLogfmtValue(buf, field.Interface)
+	}
+}
+
+// AppendLogfmtValue appends any value in logfmt form to the buffer. Values
+// without a scalar form are written as quoted JSON
+func AppendLogfmtValue(buf []byte, v any) []byte {
+	switch val := v.(type) {
+	case string:
+		return AppendLogfmtString(buf, val)
+	case error:
+		return AppendLogfmtString(buf, val.Error())
+	case nil:
+		return append(buf, "null"...)
+	case int, int32, int64, uint, uint32, uint64, float32, float64, bool:
+		return appendAny(buf, val)
+	default:
+		encoded := GetBuf(256)
+		*encoded = AppendJSONValue((*encoded)[:0], val)
+		buf = AppendLogfmtString(buf, string(*encoded))
+		PutBuf(encoded)
+		return buf
+	}
+}
+
 // JSON formatting functions
 
-// BuildStructuredHeader builds a JSON header for structured logging
-func BuildStructuredHeader(buf *[]byte, now time.Time, levelStr string, includeFileInfo bool, file string, line int) {
-	*buf = append(*buf, `{"timestamp":"`...)
-	*buf = append(*buf, now.Format(timestampFormat)...)
-	*buf = append(*buf, `","level":"`...)
-	*buf = append(*buf, levelStr...)
-	*buf = append(*buf, '"')
+// JSONHeaderKeys renders the keys of the JSON header
+func JSONHeaderKeys(keys HeaderKeys) HeaderKeys {
+	return renderHeaderKeys(keys, func(key string) string {
+		return string(AppendJSONKey(nil, key))
+	})
+}
 
-	if includeFileInfo {
-		*buf = append(*buf, `,"caller":"`...)
-		*buf = append(*buf, file...)
+// BuildStructuredHeader builds a JSON header for structured logging. Every
+// element starts with a comma; callers replace the first comma of the object
+// with its opening brace. The timestamp is an encoded JSON value; an empty one
+// is left out
+func BuildStructuredHeader(buf *[]byte, keys *HeaderKeys, timestamp []byte, levelStr, name string, includeFileInfo bool, file string, line int) {
+	if len(timestamp) > 0 && keys.Time != "" {
+		*buf = append(*buf, keys.Time...)
+		*buf = append(*buf, timestamp...)
+	}
+
+	if keys.Level != "" {
+		*buf = append(*buf, keys.Level...)
+		*buf = AppendQuoted(*buf, levelStr)
+	}
+
+	if name != "" && keys.Name != "" {
+		*buf = append(*buf, keys.Name...)
+		*buf = AppendQuoted(*buf, name)
+	}
+
+	if includeFileInfo && keys.Caller != "" {
+		*buf = append(*buf, keys.Caller...)
+		*buf = append(*buf, '"')
+		*buf = AppendJSONString(*buf, file)
 		*buf = append(*buf, ':')
 		*buf = strconv.AppendInt(*buf, int64(line), 10)
 		*buf = append(*buf, '"')
@@ -105,19 +485,95 @@ func BuildStructuredHeader(buf *[]byte, now time.Time, levelStr string, includeF
 // AppendJSONKey appends a JSON key to the buffer
 func AppendJSONKey(buf []byte, key string) []byte {
 	buf = append(buf, ',', '"')
-	buf = append(buf, key...)
+	buf = AppendJSONString(buf, key)
 	buf = append(buf, `":`...)
 	return buf
 }
 
-// AppendQuoted appends a quoted string to the buffer
+// AppendQuoted appends a quoted and escaped string to the buffer
 func AppendQuoted(buf []byte, s string) []byte {
 	buf = append(buf, '"')
-	buf = append(buf, s...)
+	buf = AppendJSONString(buf, s)
 	buf = append(buf, '"')
 	return buf
 }
 
+const hexDigits = "0123456789abcdef"
+
+// AppendJSONString appends s to the buffer escaped for use inside a JSON
+// string, following RFC 8259. Quotes, backslashes and control characters are
+// escaped, U+2028 and U+2029 are escaped for JavaScript consumers, and each
+// byte of an invalid UTF-8 sequence is replaced by U+FFFD. Strings that need
+// no escaping, such as most ASCII text, are copied as they are
+func AppendJSONString(buf []byte, s string) []byte {
+	i := 0
+	for i < len(s) {
+		if c := s[i]; c < ' ' || c == '"' || c == '\\' || c >= utf8.RuneSelf {
+			break
+		}
+		i++
+	}
+	if i == len(s) {
+		return append(buf, s...)
+	}
+
+	buf = append(buf, s[:i]...)
+	for i < len(s) {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch c {
+			case '"', '\\':
+				buf = append(buf, '\\', c)
+			case '\n':
+				buf = append(buf, '\\', 'n')
+			case '\r':
+				buf = append(buf, '\\', 'r')
+			case '\t':
+				buf = append(buf, '\\', 't')
+			case '\b':
+				buf = append(buf, '\\', 'b')
+			case '\f':
+				buf = append(buf, '\\', 'f')
+			default:
+				if c < ' ' {
+					buf = append(buf, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+				} else {
+					buf = append(buf, c)
+				}
+			}
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf = append(buf, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			buf = append(buf, '\\', 'u', '2', '0', '2', hexDigits[r&0xf])
+		default:
+			buf = append(buf, s[i:i+size]...)
+		}
+		i += size
+	}
+	return buf
+}
+
+// AppendJSONFloat appends a float as a JSON number to the buffer. NaN and
+// infinities, which JSON numbers cannot represent, are written as the
+// strings "NaN", "+Inf" and "-Inf"
+func AppendJSONFloat(buf []byte, f float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(f):
+		return append(buf, `"NaN"`...)
+	case math.IsInf(f, 1):
+		return append(buf, `"+Inf"`...)
+	case math.IsInf(f, -1):
+		return append(buf, `"-Inf"`...)
+	}
+	return strconv.AppendFloat(buf, f, 'f', -1, bitSize)
+}
+
 // AppendJSONValue appends any value as JSON to the buffer
 func AppendJSONValue(buf []byte, v any) []byte {
 	switch val := v.(type) {
@@ -128,9 +584,9 @@ func AppendJSONValue(buf []byte, v any) []byte {
 	case uint, uint32, uint64:
 		return strconv.AppendUint(buf, toUint64(val), 10)
 	case float32:
-		return strconv.AppendFloat(buf, float64(val), 'f', -1, 32)
+		return AppendJSONFloat(buf, float64(val), 32)
 	case float64:
-		return strconv.AppendFloat(buf, val, 'f', -1, 64)
+		return AppendJSONFloat(buf, val, 64)
 	case bool:
 		if val {
 			return append(buf, "true"...)
@@ -141,10 +597,12 @@ func AppendJSONValue(buf []byte, v any) []byte {
 	case nil:
 		return append(buf, "null"...)
 	case []byte:
-		if json.Valid(val) {
-			return append(buf, val...)
+		// Valid JSON is embedded compacted, so that it cannot break the line
+		dst := bytes.NewBuffer(buf)
+		if err := json.Compact(dst, val); err == nil {
+			return dst.Bytes()
 		}
-		return AppendQuoted(buf, string(val))
+		return AppendQuoted(dst.Bytes(), string(val))
 	default:
 		jsonData, err := json.Marshal(val)
 		if err != nil {
@@ -162,7 +620,7 @@ func AppendTypedJSONValue(buf []byte, field *Data) []byte {
 	case IntType, DurationType:
 		return strconv.AppendInt(buf, field.Integer, 10)
 	case FloatType:
-		return strconv.AppendFloat(buf, field.Float, 'f', -1, 64)
+		return AppendJSONFloat(buf, field.Float, 64)
 	case BoolType:
 		if field.Bool {
 			return append(buf, "true"...)
@@ -173,11 +631,85 @@ func AppendTypedJSONValue(buf []byte, field *Data) []byte {
 			return AppendQuoted(buf, t.Format(time.RFC3339Nano))
 		}
 		return append(buf, "null"...)
+	case UintType:
+		return strconv.AppendUint(buf, uint64(field.Integer), 10)
+	case Float32Type:
+		return AppendJSONFloat(buf, field.Float, 32)
+	case BytesType:
+		// Base64 and hex output never needs escaping
+		buf = append(buf, '"')
+		buf = AppendBytes(buf, field)
+		return append(buf, '"')
+	case StringerType:
+		return AppendQuoted(buf, StringerValue(field.Interface))
+	case ComplexType:
+		buf = append(buf, '"')
+		buf = AppendComplex(buf, field)
+		return append(buf, '"')
+	case NilType:
+		return append(buf, "null"...)
 	default:
 		return AppendJSONValue(buf, field.Interface)
 	}
 }
 
+// Typed value helpers
+
+// Encodings of BytesType fields, stored in the Integer of the field
+const (
+	BytesBase64 = iota
+	BytesHex
+)
+
+// AppendBytes appends the bytes of a BytesType field in its encoding
+func AppendBytes(buf []byte, field *Data) []byte {
+	b, _ := field.Interface.([]byte)
+	if field.Integer == BytesHex {
+		return hex.AppendEncode(buf, b)
+	}
+	return base64.StdEncoding.AppendEncode(buf, b)
+}
+
+// appendEncodedBytes appends the bytes of a BytesType field in its encoding
+// using appendString, which quotes the result when needed
+func appendEncodedBytes(buf []byte, field *Data, appendString func([]byte, string) []byte) []byte {
+	encoded := GetBuf(64)
+	*encoded = AppendBytes((*encoded)[:0], field)
+	// The string does not outlive the buffer, which is not modified meanwhile
+	buf = appendString(buf, unsafe.String(unsafe.SliceData(*encoded), len(*encoded)))
+	PutBuf(encoded)
+	return buf
+}
+
+// StringerValue returns the result of the String method of v, which is
+// called only when the field is written. A panicking String method, such as
+// one called on a nil pointer, yields a placeholder instead
+func StringerValue(v any) (s string) {
+	stringer, ok := v.(interface{ String() string })
+	if !ok {
+		return "<nil>"
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s = "<panic: " + Sprint(r) + ">"
+		}
+	}()
+	return stringer.String()
+}
+
+// AppendComplex appends a ComplexType field, whose real part is stored in
+// Float and imaginary part in the bits of Integer, as "re+imi"
+func AppendComplex(buf []byte, field *Data) []byte {
+	buf = strconv.AppendFloat(buf, field.Float, 'f', -1, 64)
+	start := len(buf)
+	buf = strconv.AppendFloat(append(buf, '+'), math.Float64frombits(uint64(field.Integer)), 'f', -1, 64)
+	if c := buf[start+1]; c == '+' || c == '-' {
+		// Negative parts and infinities carry their own sign
+		buf = append(buf[:start], buf[start+1:]...)
+	}
+	return append(buf, 'i')
+}
+
 // General purpose formatting functions
 
 // appendAny appends any value to the buffer as a string representation
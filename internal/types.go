@@ -3,7 +3,7 @@ package internal
 import "time"
 
 const (
-	timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+	TimestampFormat = "2006-01-02T15:04:05.000Z07:00"
 )
 
 // FieldType represents the type of a log field
@@ -18,6 +18,15 @@ const (
 	ErrorType
 	DurationType
 	TimeType
+	ObjectType
+	ArrayType
+	UintType
+	Float32Type
+	BytesType
+	StringerType
+	ComplexType
+	NilType
+	LazyType
 )
 
 // Data represents a typed log field
@@ -7,79 +7,229 @@ package log
 
 import (
 	"io"
+	"log/slog"
 	"os"
+	"sync/atomic"
+	"time"
 
 	"github.com/nszilard/log/internal"
 )
 
 type logger struct {
-	internal        *internal.Logger
-	currentLevel    Level
-	includeFileInfo bool
+	// Cores writing entries and the bound fields encoded for them, replaced
+	// as a whole when the format or the output changes.
+	cores *atomic.Pointer[coreSet]
+
+	// Configuration of the built-in encoders, nil for loggers given a Core.
+	encoderConfig *EncoderConfig
+
+	level           *levelNode
+	includeFileInfo *atomic.Bool
+	now             func() time.Time
+
+	// Dotted name of the logger, empty for root loggers.
+	name string
+
+	// Fields bound with With, encoded once by the encoder of each core.
+	// Bound fields from the first lazy one on are kept in lazyContext
+	// instead and resolved for every entry.
+	context     []Data
+	lazyContext []Data
+
+	// Hooks indexed by level, and where their errors are reported.
+	hooks       *atomic.Pointer[hookTable]
+	errorOutput io.Writer
+
+	// Handler that receives entries instead of the output, if set.
+	slogSink slog.Handler
+
+	// Function called by the Fatal methods to terminate the process.
+	exit *atomic.Pointer[func(code int)]
+
+	// Whether the DPanic methods panic.
+	development *atomic.Bool
+
+	// Level at and above which entries carry a stack trace, nil for none.
+	stackLevel *AtomicLevel
+}
+
+// coreSet holds the cores of a logger and the fields bound to it, encoded by
+// the encoder of each core. Sets are never modified once published.
+type coreSet struct {
+	format Format
+
+	// Cores writing plain and structured entries. They are the same core
+	// unless the logger uses the built-in encoders, and always share a sink.
+	core           *Core
+	structuredCore *Core
+
+	coreContext       []byte
+	structuredContext []byte
 }
 
 // std is the default logger instance.
-var std = &logger{
-	internal:        internal.New(os.Stdout),
-	currentLevel:    InfoLevel,
-	includeFileInfo: true,
+var std = newLogger(InfoLevel, os.Stdout)
+
+// Default returns the default logger used by the package-level functions.
+func Default() Logger {
+	return std
 }
 
 // SetLevel sets the minimum level for the default logger.
 func SetLevel(level Level) {
-	std.currentLevel = level
+	std.SetLevel(level)
 }
 
 // SetOutput sets the output destination for the default logger.
 func SetOutput(out io.Writer) {
-	std.internal.SetOutput(out)
+	std.SetOutput(out)
 }
 
 // SetIncludeFileInfo sets whether to include file and line information in logs.
 func SetIncludeFileInfo(include bool) {
-	std.includeFileInfo = include
+	std.SetIncludeFileInfo(include)
 }
 
-// Panic logs a message at PanicLevel and then panics.
+// Log logs a message at the given level, which may be a registered level.
+// Unlike Panic and Fatal, it never panics or exits, whatever the level.
+func Log(level Level, v ...any) {
+	if std.enabled(level) {
+		std.write(level, internal.Sprint(v...))
+	}
+}
+
+// Panic logs a message at PanicLevel and then panics with a *PanicError.
 func Panic(v ...any) {
 	msg := internal.Sprint(v...)
-	if PanicLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(PanicLevel.String(), msg, std.includeFileInfo)
+	if std.enabled(PanicLevel) {
+		std.write(PanicLevel, msg)
 	}
-	panic(msg)
+	panic(newPanicError(PanicLevel, msg, nil))
 }
 
-// Fatal logs a message at FatalLevel.
+// Fatal logs a message at FatalLevel, then flushes the output, runs the exit
+// hooks and calls the exit function. Use Log(FatalLevel, ...) to log at
+// FatalLevel without exiting.
 func Fatal(v ...any) {
-	if FatalLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(FatalLevel.String(), internal.Sprint(v...), std.includeFileInfo)
+	if std.enabled(FatalLevel) {
+		std.write(FatalLevel, internal.Sprint(v...))
 	}
+	std.terminate()
 }
 
 // Error logs a message at ErrorLevel.
 func Error(v ...any) {
-	if ErrorLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(ErrorLevel.String(), internal.Sprint(v...), std.includeFileInfo)
+	if std.enabled(ErrorLevel) {
+		std.write(ErrorLevel, internal.Sprint(v...))
 	}
 }
 
 // Warn logs a message at WarnLevel.
 func Warn(v ...any) {
-	if WarnLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(WarnLevel.String(), internal.Sprint(v...), std.includeFileInfo)
+	if std.enabled(WarnLevel) {
+		std.write(WarnLevel, internal.Sprint(v...))
 	}
 }
 
 // Info logs a message at InfoLevel.
 func Info(v ...any) {
-	if InfoLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(InfoLevel.String(), internal.Sprint(v...), std.includeFileInfo)
+	if std.enabled(InfoLevel) {
+		std.write(InfoLevel, internal.Sprint(v...))
 	}
 }
 
 // Debug logs a message at DebugLevel.
 func Debug(v ...any) {
-	if DebugLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(DebugLevel.String(), internal.Sprint(v...), std.includeFileInfo)
+	if std.enabled(DebugLevel) {
+		std.write(DebugLevel, internal.Sprint(v...))
+	}
+}
+
+// SetLevel sets the minimum level for the logger. On a named logger it
+// overrides the inherited level for that name and all names below it.
+// On a root logger it changes the logger's AtomicLevel, and with it every
+// logger sharing that AtomicLevel. Children created with With share the
+// level of their parent, so SetLevel on either changes both.
+func (l *logger) SetLevel(level Level) {
+	l.level.set(level)
+}
+
+// SetOutput sets the output destination for the logger and every logger
+// sharing its output, which includes its parent and children created with
+// With or Named. It has no effect on loggers given a Core whose sink was not
+// created with NewWriterSink. In FormatConsole, whether entries are colored
+// is decided again for out.
+func (l *logger) SetOutput(out io.Writer) {
+	cores := l.cores.Load()
+	if sink, ok := cores.core.sink.(*writerSink); ok {
+		sink.setOutput(out)
+		if cores.format == FormatConsole {
+			l.buildCores()
+		}
+	}
+}
+
+// Sync flushes any buffered output of the logger.
+func (l *logger) Sync() error {
+	return l.cores.Load().core.Sync()
+}
+
+// SetIncludeFileInfo sets whether to include file and line information in logs.
+func (l *logger) SetIncludeFileInfo(include bool) {
+	l.includeFileInfo.Store(include)
+}
+
+// Log logs a message at the given level, which may be a registered level.
+// Unlike Panic and Fatal, it never panics or exits, whatever the level.
+func (l *logger) Log(level Level, v ...any) {
+	if l.enabled(level) {
+		l.write(level, internal.Sprint(v...))
+	}
+}
+
+// Panic logs a message at PanicLevel and then panics with a *PanicError.
+func (l *logger) Panic(v ...any) {
+	msg := internal.Sprint(v...)
+	if l.enabled(PanicLevel) {
+		l.write(PanicLevel, msg)
+	}
+	panic(newPanicError(PanicLevel, msg, nil))
+}
+
+// Fatal logs a message at FatalLevel, then flushes the output, runs the exit
+// hooks and calls the exit function. Use Log(FatalLevel, ...) to log at
+// FatalLevel without exiting.
+func (l *logger) Fatal(v ...any) {
+	if l.enabled(FatalLevel) {
+		l.write(FatalLevel, internal.Sprint(v...))
+	}
+	l.terminate()
+}
+
+// Error logs a message at ErrorLevel.
+func (l *logger) Error(v ...any) {
+	if l.enabled(ErrorLevel) {
+		l.write(ErrorLevel, internal.Sprint(v...))
+	}
+}
+
+// Warn logs a message at WarnLevel.
+func (l *logger) Warn(v ...any) {
+	if l.enabled(WarnLevel) {
+		l.write(WarnLevel, internal.Sprint(v...))
+	}
+}
+
+// Info logs a message at InfoLevel.
+func (l *logger) Info(v ...any) {
+	if l.enabled(InfoLevel) {
+		l.write(InfoLevel, internal.Sprint(v...))
+	}
+}
+
+// Debug logs a message at DebugLevel.
+func (l *logger) Debug(v ...any) {
+	if l.enabled(DebugLevel) {
+		l.write(DebugLevel, internal.Sprint(v...))
 	}
 }
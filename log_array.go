@@ -0,0 +1,214 @@
+package log
+
+import (
+	"strconv"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/nszilard/log/internal"
+)
+
+// ArrayMarshaler is implemented by types that write themselves as an array
+// of typed elements, avoiding the reflection used for WithAny values.
+type ArrayMarshaler interface {
+	MarshalLogArray(enc ArrayEncoder) error
+}
+
+// ArrayMarshalerFunc adapts a function to the ArrayMarshaler interface.
+type ArrayMarshalerFunc func(enc ArrayEncoder) error
+
+// MarshalLogArray calls f.
+func (f ArrayMarshalerFunc) MarshalLogArray(enc ArrayEncoder) error {
+	return f(enc)
+}
+
+// ArrayEncoder receives the elements of an array. Arrays are written as JSON
+// arrays by every encoder; logfmt quotes them when needed.
+type ArrayEncoder interface {
+	AppendString(v string)
+	AppendInt(v int64)
+	AppendFloat(v float64)
+	AppendFloat32(v float32)
+	AppendBool(v bool)
+	AppendDuration(v time.Duration)
+	AppendTime(v time.Time)
+	// AppendObject appends a nested object, returning the error of its marshaler.
+	AppendObject(v ObjectMarshaler) error
+	// AppendArray appends a nested array, returning the error of its marshaler.
+	AppendArray(v ArrayMarshaler) error
+}
+
+// stringArray is the array of WithStrings.
+type stringArray []string
+
+// MarshalLogArray appends the strings.
+func (a stringArray) MarshalLogArray(enc ArrayEncoder) error {
+	for _, v := range a {
+		enc.AppendString(v)
+	}
+	return nil
+}
+
+// intArray is the array of WithInts.
+type intArray[T ~int | ~int8 | ~int16 | ~int32 | ~int64] []T
+
+// MarshalLogArray appends the integers.
+func (a intArray[T]) MarshalLogArray(enc ArrayEncoder) error {
+	for _, v := range a {
+		enc.AppendInt(int64(v))
+	}
+	return nil
+}
+
+// floatArray is the array of WithFloats.
+type floatArray[T ~float32 | ~float64] []T
+
+// MarshalLogArray appends the floats.
+func (a floatArray[T]) MarshalLogArray(enc ArrayEncoder) error {
+	for _, v := range a {
+		if unsafe.Sizeof(v) == 4 {
+			enc.AppendFloat32(float32(v))
+		} else {
+			enc.AppendFloat(float64(v))
+		}
+	}
+	return nil
+}
+
+// durationArray is the array of WithDurations.
+type durationArray []time.Duration
+
+// MarshalLogArray appends the durations.
+func (a durationArray) MarshalLogArray(enc ArrayEncoder) error {
+	for _, v := range a {
+		enc.AppendDuration(v)
+	}
+	return nil
+}
+
+// arrayMarshaler returns the marshaler of an array field, or of an untyped
+// field whose value implements ArrayMarshaler.
+func arrayMarshaler(field *internal.Data) (ArrayMarshaler, bool) {
+	if field.Type != internal.ArrayType && field.Type != internal.UnknownType {
+		return nil, false
+	}
+	m, ok := field.Interface.(ArrayMarshaler)
+	return m, ok
+}
+
+// jsonArrayEncoder writes the elements of an array as JSON values.
+type jsonArrayEncoder struct {
+	buf    []byte
+	empty  bool
+	fields *fieldEncoding
+}
+
+var jsonArrayEncoderPool = sync.Pool{New: func() any { return &jsonArrayEncoder{} }}
+
+// separate appends the separator before an element. The opening bracket is
+// written with the first element.
+func (enc *jsonArrayEncoder) separate() {
+	if enc.empty {
+		enc.buf = append(enc.buf, '[')
+		enc.empty = false
+	} else {
+		enc.buf = append(enc.buf, ',')
+	}
+}
+
+// AppendString appends a JSON string.
+func (enc *jsonArrayEncoder) AppendString(v string) {
+	enc.separate()
+	enc.buf = internal.AppendQuoted(enc.buf, v)
+}
+
+// AppendInt appends a JSON number.
+func (enc *jsonArrayEncoder) AppendInt(v int64) {
+	enc.separate()
+	enc.buf = strconv.AppendInt(enc.buf, v, 10)
+}
+
+// AppendFloat appends a JSON number, or a string for NaN and infinities.
+func (enc *jsonArrayEncoder) AppendFloat(v float64) {
+	enc.separate()
+	enc.buf = internal.AppendJSONFloat(enc.buf, v, 64)
+}
+
+// AppendFloat32 appends a JSON number with the precision of a float32.
+func (enc *jsonArrayEncoder) AppendFloat32(v float32) {
+	enc.separate()
+	enc.buf = internal.AppendJSONFloat(enc.buf, float64(v), 32)
+}
+
+// AppendBool appends true or false.
+func (enc *jsonArrayEncoder) AppendBool(v bool) {
+	enc.separate()
+	enc.buf = strconv.AppendBool(enc.buf, v)
+}
+
+// AppendDuration appends a duration the way WithDuration fields are written.
+func (enc *jsonArrayEncoder) AppendDuration(v time.Duration) {
+	enc.separate()
+	enc.buf = appendJSONDuration(enc.buf, v, enc.fields.duration)
+}
+
+// AppendTime appends a time the way WithTime fields are written.
+func (enc *jsonArrayEncoder) AppendTime(v time.Time) {
+	enc.separate()
+	enc.buf = enc.fields.time.appendJSON(enc.buf, v)
+}
+
+// AppendObject appends a JSON object.
+func (enc *jsonArrayEncoder) AppendObject(v ObjectMarshaler) error {
+	enc.separate()
+	var err error
+	enc.buf, err = appendJSONObject(enc.buf, v, enc.fields)
+	return err
+}
+
+// AppendArray appends a JSON array.
+func (enc *jsonArrayEncoder) AppendArray(v ArrayMarshaler) error {
+	enc.separate()
+	var err error
+	enc.buf, err = appendJSONArray(enc.buf, v, enc.fields)
+	return err
+}
+
+// appendJSONArray appends the elements added by m as a JSON array.
+func appendJSONArray(buf []byte, m ArrayMarshaler, fe *fieldEncoding) ([]byte, error) {
+	enc := jsonArrayEncoderPool.Get().(*jsonArrayEncoder)
+	enc.buf, enc.empty, enc.fields = buf, true, fe
+	err := m.MarshalLogArray(enc)
+	buf = enc.buf
+	if enc.empty {
+		buf = append(buf, '[')
+	}
+	buf = append(buf, ']')
+	enc.buf, enc.fields = nil, nil
+	jsonArrayEncoderPool.Put(enc)
+	return buf, err
+}
+
+// appendFlatArray appends an array as a " prefixkey=value" pair whose value
+// is the JSON array, quoted when needed in logfmt. A failing marshaler is
+// followed by a "<key>Error" pair.
+func appendFlatArray(buf []byte, prefix, key string, m ArrayMarshaler, style flatStyle, fe *fieldEncoding) []byte {
+	var err error
+	if style == logfmtStyle {
+		buf = internal.AppendLogfmtKey(buf, prefix, key)
+		encoded := internal.GetBuf(64)
+		*encoded, err = appendJSONArray((*encoded)[:0], m, fe)
+		// The string does not outlive the buffer, which is not modified meanwhile
+		buf = internal.AppendLogfmtString(buf, unsafe.String(unsafe.SliceData(*encoded), len(*encoded)))
+		internal.PutBuf(encoded)
+	} else {
+		buf = internal.AppendTextKey(buf, prefix, key)
+		buf, err = appendJSONArray(buf, m, fe)
+	}
+	if err != nil {
+		errField := internal.StringField(key+"Error", err.Error())
+		buf = appendFlatField(buf, prefix, &errField, style, fe)
+	}
+	return buf
+}
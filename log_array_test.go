@@ -0,0 +1,102 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+type shard int32
+
+func TestArrayFields(t *testing.T) {
+	fields := []Data{
+		WithStrings("ids", []string{"a1", "b 2"}),
+		WithInts("shards", []shard{3, 7}),
+		WithFloats("ratios", []float32{0.1, 2}),
+		WithDurations("waits", []time.Duration{time.Millisecond}),
+	}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{
+			"JSON",
+			NewJSONEncoder(EncoderConfig{}),
+			`,"ids":["a1","b 2"],"shards":[3,7],"ratios":[0.1,2],"waits":[1000000]`,
+		},
+		{
+			"Text",
+			NewTextEncoder(EncoderConfig{}),
+			` ids=["a1","b 2"] shards=[3,7] ratios=[0.1,2] waits=["1ms"]`,
+		},
+		{
+			"Logfmt",
+			NewLogfmtEncoder(EncoderConfig{}),
+			` ids="[\"a1\",\"b 2\"]" shards=[3,7] ratios=[0.1,2] waits="[\"1ms\"]"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.encoder.EncodeFields(nil, fields)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestArrayMarshaler(t *testing.T) {
+	nested := ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+		enc.AppendBool(true)
+		enc.AppendFloat(math.Inf(1))
+		enc.AppendTime(time.Date(2025, 9, 25, 13, 20, 18, 0, time.UTC))
+		if err := enc.AppendObject(request{method: "GET", status: 200}); err != nil {
+			return err
+		}
+		return enc.AppendArray(WithInts("", []int{1}).Interface.(ArrayMarshaler))
+	})
+	failing := ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+		enc.AppendString("partial")
+		return errors.New("truncated")
+	})
+
+	tests := []struct {
+		name  string
+		field Data
+		json  string
+		text  string
+	}{
+		{"Empty", WithStrings("ids", nil), `"ids":[]`, ` ids=[]`},
+		{"Nested", WithArray("values", nested), `"values":[true,"+Inf","2025-09-25T13:20:18Z",{"method":"GET","status":200},[1]]`, ` values=[true,"+Inf","2025-09-25T13:20:18Z",{"method":"GET","status":200},[1]]`},
+		{"Error", WithArray("rows", failing), `"rows":["partial"],"rowsError":"truncated"`, ` rows=["partial"] rowsError=truncated`},
+		{"Untyped marshaler", WithAny("ids", stringArray{"x"}), `"ids":["x"]`, ` ids=["x"]`},
+		{"In group", WithGroup("req", WithInts("ids", []int64{1, 2})), `"req":{"ids":[1,2]}`, ` req.ids=[1,2]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := []Data{tt.field}
+			if got := string(NewJSONEncoder(EncoderConfig{}).EncodeFields(nil, fields)); got != ","+tt.json {
+				t.Errorf("Expected JSON %q, got %q", ","+tt.json, got)
+			}
+			if got := string(NewTextEncoder(EncoderConfig{}).EncodeFields(nil, fields)); got != tt.text {
+				t.Errorf("Expected text %q, got %q", tt.text, got)
+			}
+		})
+	}
+}
+
+func TestArrayWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false))
+
+	logger.InfoS(WithInts("shards", []int{1, 2, 3}), WithError("err", nil))
+	want := `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","shards":[1,2,3],"err":""}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
@@ -0,0 +1,91 @@
+package log
+
+import (
+	"log/slog"
+	"strings"
+	"sync/atomic"
+)
+
+// With returns a child of the default logger that adds fields to every entry.
+func With(fields ...Data) Logger {
+	return std.With(fields...)
+}
+
+// With returns a child logger that adds fields to every entry it writes,
+// before the entry's own fields. The fields are encoded once, when the child
+// is created. Lazy fields, and the fields bound after them, are instead
+// resolved for every entry written.
+//
+// The child shares the level and the output of its parent: SetLevel and
+// SetOutput on the child change them for the parent and its other children
+// too. Use Named for a child with a level of its own.
+func (l *logger) With(fields ...Data) Logger {
+	child := l.clone()
+	child.context = append(child.context[:len(child.context):len(child.context)], fields...)
+
+	eager := fields
+	if len(l.lazyContext) > 0 {
+		eager = nil
+	} else if i := firstLazy(fields); i >= 0 {
+		eager = fields[:i]
+	}
+	if lazy := fields[len(eager):]; len(lazy) > 0 {
+		child.lazyContext = append(child.lazyContext[:len(child.lazyContext):len(child.lazyContext)], lazy...)
+	}
+
+	cores := *l.cores.Load()
+	cores.encodeContext(eager)
+	child.cores.Store(&cores)
+	if l.slogSink != nil && len(eager) > 0 {
+		attrs := make([]slog.Attr, len(eager))
+		for i, field := range eager {
+			attrs[i] = toSlogAttr(field)
+		}
+		child.slogSink = l.slogSink.WithAttrs(attrs)
+	}
+	return child
+}
+
+// Named returns a child of the default logger with the given name.
+func Named(name string) Logger {
+	return std.Named(name)
+}
+
+// Named returns a child logger whose name is the logger's name extended with
+// name, joined by a dot. Dots in name create several levels at once, so
+// Named("db.pool") is the same as Named("db").Named("pool").
+//
+// The name is written in the header of every entry. Loggers with the same
+// name share their level: calling SetLevel on a named logger affects every
+// logger with that name and, unless they set their own, all loggers below it.
+func (l *logger) Named(name string) Logger {
+	child := l.clone()
+	for segment := range strings.SplitSeq(name, ".") {
+		if segment == "" {
+			continue
+		}
+		child.level = child.level.child(segment)
+		if child.name == "" {
+			child.name = segment
+		} else {
+			child.name += "." + segment
+		}
+	}
+	return child
+}
+
+// clone returns a copy of the logger that can be modified without affecting the original.
+func (l *logger) clone() *logger {
+	child := *l
+	child.includeFileInfo = &atomic.Bool{}
+	child.includeFileInfo.Store(l.includeFileInfo.Load())
+	child.hooks = &atomic.Pointer[hookTable]{}
+	child.hooks.Store(l.hooks.Load())
+	child.exit = &atomic.Pointer[func(code int)]{}
+	child.exit.Store(l.exit.Load())
+	child.development = &atomic.Bool{}
+	child.development.Store(l.development.Load())
+	child.cores = &atomic.Pointer[coreSet]{}
+	child.cores.Store(l.cores.Load())
+	return &child
+}
@@ -0,0 +1,229 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionIncludeFileInfo(false))
+	child := logger.With(WithString("request_id", "abc"), WithInt("attempt", 2))
+
+	t.Run("Structured", func(t *testing.T) {
+		buf.Reset()
+		child.InfoS(WithString("user", "john"))
+		expected := `"level":"INFO","request_id":"abc","attempt":2,"user":"john"}` + "\n"
+		if !strings.HasSuffix(buf.String(), expected) {
+			t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Structured untyped", func(t *testing.T) {
+		buf.Reset()
+		child.InfoS(WithAny("user", "john"))
+		expected := `"request_id":"abc","attempt":2,"user":"john"}` + "\n"
+		if !strings.HasSuffix(buf.String(), expected) {
+			t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		buf.Reset()
+		child.Infof("user %s logged in", "john")
+		expected := "▶ user john logged in request_id=abc attempt=2\n"
+		if !strings.HasSuffix(buf.String(), expected) {
+			t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Text trailing newline", func(t *testing.T) {
+		buf.Reset()
+		child.Info("done\n")
+		expected := "▶ done request_id=abc attempt=2\n"
+		if !strings.HasSuffix(buf.String(), expected) {
+			t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Parent unchanged", func(t *testing.T) {
+		buf.Reset()
+		logger.InfoS(WithString("user", "john"))
+		if strings.Contains(buf.String(), "request_id") {
+			t.Errorf("Parent should not carry child fields: %q", buf.String())
+		}
+	})
+}
+
+func TestWithNested(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(InfoLevel, &buf, OptionIncludeFileInfo(false)).With(WithString("service", "api"))
+	first := parent.With(WithString("tenant", "a"))
+	second := parent.With(WithString("tenant", "b"))
+
+	first.InfoS(WithBool("ok", true))
+	second.InfoS(WithBool("ok", true))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], `"service":"api","tenant":"a","ok":true`) {
+		t.Errorf("Unexpected first line: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"service":"api","tenant":"b","ok":true`) {
+		t.Errorf("Unexpected second line: %s", lines[1])
+	}
+}
+
+func TestWithFieldTypes(t *testing.T) {
+	var buf bytes.Buffer
+	testTime := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	child := New(InfoLevel, &buf, OptionIncludeFileInfo(false)).With(
+		WithString("str", "hello world"),
+		WithFloat("float", 3.14),
+		WithBool("flag", true),
+		WithError("err", errors.New("boom")),
+		WithDuration("dur", 1500*time.Millisecond),
+		WithTime("time", testTime),
+		WithAny("any", []int{1, 2}),
+	)
+
+	child.Info("msg")
+	expected := `msg str="hello world" float=3.14 flag=true err=boom dur=1.5s time=2023-01-01T12:00:00Z any=[1,2]` + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+	}
+
+	buf.Reset()
+	child.InfoS(WithInt("n", 1))
+	expected = `"str":"hello world","float":3.14,"flag":true,"err":"boom","dur":1500000000,"time":"2023-01-01T12:00:00Z","any":[1,2],"n":1}` + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+	}
+}
+
+func TestWithDefaultLogger(t *testing.T) {
+	buf, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	With(WithString("component", "auth")).Warn("denied")
+	if !strings.Contains(buf.String(), "denied component=auth") {
+		t.Errorf("Expected bound field in output: %q", buf.String())
+	}
+}
+
+func TestWithSharesLevelAndOutput(t *testing.T) {
+	var buf, other bytes.Buffer
+	parent := New(InfoLevel, &buf, OptionIncludeFileInfo(false))
+	child := parent.With(WithString("request_id", "abc"))
+
+	child.SetLevel(DebugLevel)
+	child.SetOutput(&other)
+	child.SetIncludeFileInfo(true)
+	parent.Debug("parent")
+	if buf.Len() > 0 || !strings.Contains(other.String(), "[DEBUG] ▶ parent") {
+		t.Errorf("Expected the parent to follow the level and output of the child, got %q and %q", buf.String(), other.String())
+	}
+
+	other.Reset()
+	parent.SetLevel(InfoLevel)
+	child.Debug("filtered")
+	child.Info("child")
+	if !strings.Contains(other.String(), "[INFO] (log_child_test.go:") || strings.Contains(other.String(), "filtered") {
+		t.Errorf("Expected the child to follow the level of the parent and keep its file info, got %q", other.String())
+	}
+}
+
+func TestNamed(t *testing.T) {
+	var buf bytes.Buffer
+	root := New(InfoLevel, &buf, OptionIncludeFileInfo(false))
+	pool := root.Named("db").Named("pool")
+
+	t.Run("Structured", func(t *testing.T) {
+		buf.Reset()
+		pool.InfoS(WithInt("size", 10))
+		expected := `"level":"INFO","logger":"db.pool","size":10}` + "\n"
+		if !strings.HasSuffix(buf.String(), expected) {
+			t.Errorf("Expected suffix %q in output: %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		buf.Reset()
+		pool.Info("ready")
+		if !strings.Contains(buf.String(), "[INFO] [db.pool] ▶ ready\n") {
+			t.Errorf("Expected name tag in output: %q", buf.String())
+		}
+	})
+
+	t.Run("Root has no name", func(t *testing.T) {
+		buf.Reset()
+		root.InfoS(WithInt("size", 10))
+		if strings.Contains(buf.String(), `"logger"`) {
+			t.Errorf("Root logger should not have a name: %q", buf.String())
+		}
+	})
+
+	t.Run("Dotted name", func(t *testing.T) {
+		buf.Reset()
+		root.Named("db.pool").Named("").InfoS(WithInt("size", 10))
+		if !strings.Contains(buf.String(), `"logger":"db.pool"`) {
+			t.Errorf("Expected dotted name in output: %q", buf.String())
+		}
+	})
+}
+
+func TestNamedLevels(t *testing.T) {
+	var buf bytes.Buffer
+	root := New(InfoLevel, &buf)
+	db := root.Named("db")
+	pool := db.Named("pool")
+	http := root.Named("http")
+
+	tests := []struct {
+		name      string
+		logger    Logger
+		shouldLog bool
+	}{
+		{"root", root, false},
+		{"db", db, true},
+		{"db.pool", pool, true},
+		{"db.pool via With", pool.With(WithString("k", "v")), true},
+		{"db.pool looked up again", root.Named("db.pool"), true},
+		{"http", http, false},
+	}
+
+	db.SetLevel(DebugLevel)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.logger.Debug("test")
+			if hasOutput := buf.Len() > 0; hasOutput != tt.shouldLog {
+				t.Errorf("Expected shouldLog=%v, got=%v", tt.shouldLog, hasOutput)
+			}
+		})
+	}
+
+	t.Run("Child override", func(t *testing.T) {
+		pool.SetLevel(ErrorLevel)
+		buf.Reset()
+		pool.Warn("test")
+		db.Warn("test")
+		if lines := strings.Count(buf.String(), "\n"); lines != 1 {
+			t.Errorf("Expected only the db entry, got %d lines: %q", lines, buf.String())
+		}
+	})
+
+	t.Run("Root change is inherited", func(t *testing.T) {
+		root.SetLevel(ErrorLevel)
+		buf.Reset()
+		http.Warn("test")
+		if buf.Len() > 0 {
+			t.Errorf("Expected http to inherit the root level, got: %q", buf.String())
+		}
+	})
+}
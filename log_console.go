@@ -0,0 +1,102 @@
+package log
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nszilard/log/internal"
+)
+
+// consoleMessageWidth is the width messages are padded to in console entries
+// with fields, so that the fields of consecutive entries line up.
+const consoleMessageWidth = 40
+
+// consoleEncoder writes entries in a human-friendly, optionally colored form.
+type consoleEncoder struct {
+	time   timeEncoder
+	fields *fieldEncoding
+	color  bool
+}
+
+// NewConsoleEncoder returns an Encoder for reading logs in a terminal. It
+// writes lines like
+//
+//	2025-09-25T13:20:18.524Z INFO  [db] pool.go:42 ▶ connection acquired      elapsed=1.5ms pool=main
+//
+// with messages padded so that the key=value pairs of consecutive entries
+// line up. With color, levels are colored by severity and timestamps, names
+// and callers are dimmed. Use ColorEnabled to decide whether the output
+// supports colors.
+func NewConsoleEncoder(cfg EncoderConfig, color bool) Encoder {
+	return &consoleEncoder{time: cfg.headerTime(), fields: cfg.fieldEncoding(DurationString), color: color}
+}
+
+// EncodeEntry appends the console line of e to buf.
+func (enc *consoleEncoder) EncodeEntry(buf []byte, e *Entry) []byte {
+	color := enc.levelColor(e.Level)
+	var ts [64]byte
+	timestamp := ts[:0]
+	if !enc.time.omitted() {
+		timestamp = enc.time.appendTime(timestamp, e.Time)
+	}
+	buf = internal.AppendConsoleHeader(buf, timestamp, e.Level.String(), color, e.LoggerName, e.File, e.Line, e.File != "")
+	if len(e.Context) == 0 && len(e.Fields) == 0 {
+		buf = append(buf, e.Message...)
+	} else {
+		// Drop a trailing newline of the message before padding it, or the
+		// space after the header when there is no message, as the fields
+		// start with a space
+		msg := strings.TrimSuffix(e.Message, "\n")
+		buf = internal.AppendConsoleMessage(buf, msg, consoleMessageWidth, true)
+		if msg == "" {
+			buf = buf[:len(buf)-1]
+		}
+		buf = append(buf, e.Context...)
+		buf = enc.EncodeFields(buf, e.Fields)
+	}
+	if buf[len(buf)-1] != '\n' {
+		buf = append(buf, '\n')
+	}
+	return appendStack(buf, e.Stack)
+}
+
+// EncodeFields appends fields as " key=value" pairs to buf.
+func (enc *consoleEncoder) EncodeFields(buf []byte, fields []Data) []byte {
+	return appendFlatFields(buf, fields, textStyle, enc.fields)
+}
+
+// levelColor returns the color of level, or an empty string without colors.
+func (enc *consoleEncoder) levelColor(level Level) string {
+	switch {
+	case !enc.color:
+		return ""
+	case level <= ErrorLevel:
+		return internal.ColorRed
+	case level <= WarnLevel:
+		return internal.ColorYellow
+	case level <= InfoLevel:
+		return internal.ColorBlue
+	default:
+		return internal.ColorMagenta
+	}
+}
+
+// ColorEnabled reports whether colored output should be written to w. It
+// returns false if the NO_COLOR environment variable is set to a non-empty
+// value, true if FORCE_COLOR is set to a non-empty value other than "0" or
+// "false", and otherwise whether w is a terminal.
+func ColorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force := os.Getenv("FORCE_COLOR"); force != "" {
+		return force != "0" && force != "false"
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,171 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConsoleEncoder(t *testing.T) {
+	entry := Entry{
+		Level:      InfoLevel,
+		Time:       fixedClock(),
+		LoggerName: "db",
+		File:       "pool.go",
+		Line:       42,
+		Message:    "connection acquired",
+		Fields:     []Data{WithDuration("elapsed", 1500*time.Microsecond), WithString("pool", "main")},
+	}
+
+	tests := []struct {
+		name     string
+		color    bool
+		modify   func(e *Entry)
+		expected string
+	}{
+		{
+			"Plain",
+			false,
+			func(*Entry) {},
+			"2025-09-25T13:20:18.524Z INFO  [db] pool.go:42 ▶ connection acquired                      elapsed=1.5ms pool=main\n",
+		},
+		{
+			"Color",
+			true,
+			func(*Entry) {},
+			"\x1b[2m2025-09-25T13:20:18.524Z\x1b[0m \x1b[34mINFO\x1b[0m  \x1b[2m[db] pool.go:42\x1b[0m ▶ connection acquired                      elapsed=1.5ms pool=main\n",
+		},
+		{
+			"NoFields",
+			true,
+			func(e *Entry) { e.Level, e.LoggerName, e.File, e.Fields = ErrorLevel, "", "", nil },
+			"\x1b[2m2025-09-25T13:20:18.524Z\x1b[0m \x1b[31mERROR\x1b[0m ▶ connection acquired\n",
+		},
+		{
+			"NoMessage",
+			false,
+			func(e *Entry) { e.Level, e.LoggerName, e.File, e.Message = DebugLevel, "", "", "" },
+			"2025-09-25T13:20:18.524Z DEBUG ▶ elapsed=1.5ms pool=main\n",
+		},
+		{
+			"Context",
+			false,
+			func(e *Entry) { e.LoggerName, e.File, e.Message, e.Context = "", "", "ready", []byte(" service=api") },
+			"2025-09-25T13:20:18.524Z INFO  ▶ ready                                    service=api elapsed=1.5ms pool=main\n",
+		},
+		{
+			"TrailingNewline",
+			false,
+			func(e *Entry) { e.LoggerName, e.File, e.Message = "", "", "ready\n" },
+			"2025-09-25T13:20:18.524Z INFO  ▶ ready                                    elapsed=1.5ms pool=main\n",
+		},
+		{
+			"TrailingNewlineNoFields",
+			false,
+			func(e *Entry) { e.LoggerName, e.File, e.Message, e.Fields = "", "", "ready\n", nil },
+			"2025-09-25T13:20:18.524Z INFO  ▶ ready\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := entry
+			tt.modify(&e)
+			if got := string(NewConsoleEncoder(EncoderConfig{}, tt.color).EncodeEntry(nil, &e)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConsoleEncoderLevelColors(t *testing.T) {
+	enc := NewConsoleEncoder(EncoderConfig{}, true).(*consoleEncoder)
+	tests := []struct {
+		level    Level
+		expected string
+	}{
+		{PanicLevel, "\x1b[31m"},
+		{FatalLevel, "\x1b[31m"},
+		{ErrorLevel, "\x1b[31m"},
+		{WarnLevel, "\x1b[33m"},
+		{InfoLevel, "\x1b[34m"},
+		{DebugLevel, "\x1b[35m"},
+		{DebugLevel + 10, "\x1b[35m"},
+	}
+	for _, tt := range tests {
+		if got := enc.levelColor(tt.level); got != tt.expected {
+			t.Errorf("Expected color %q for level %d, got %q", tt.expected, tt.level, got)
+		}
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	file, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	tests := []struct {
+		name     string
+		noColor  string
+		force    string
+		forceSet bool
+		out      io.Writer
+		expected bool
+	}{
+		{"Buffer", "", "", false, &bytes.Buffer{}, false},
+		{"RegularFile", "", "", false, file, false},
+		{"ForceColor", "", "1", true, &bytes.Buffer{}, true},
+		{"ForceColorZero", "", "0", true, &bytes.Buffer{}, false},
+		{"ForceColorFalse", "", "false", true, &bytes.Buffer{}, false},
+		{"ForceColorEmpty", "", "", true, &bytes.Buffer{}, false},
+		{"NoColorWins", "1", "1", true, &bytes.Buffer{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("NO_COLOR", tt.noColor)
+			t.Setenv("FORCE_COLOR", tt.force)
+			if !tt.forceSet {
+				os.Unsetenv("FORCE_COLOR")
+			}
+			if got := ColorEnabled(tt.out); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConsoleColorFollowsOutput(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("FORCE_COLOR", "")
+	os.Unsetenv("FORCE_COLOR")
+	// Character devices count as terminals
+	tty, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Skipf("Cannot open %s: %v", os.DevNull, err)
+	}
+	defer tty.Close()
+	if !ColorEnabled(tty) {
+		t.Skipf("%s is not a character device", os.DevNull)
+	}
+
+	var buf bytes.Buffer
+	logger := New(InfoLevel, tty, OptionFormat(FormatConsole), OptionOutput(&buf), OptionIncludeFileInfo(false))
+	logger.Info("redirected")
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("Expected no colors after OptionOutput, got %q", buf.String())
+	}
+
+	logger.SetOutput(tty)
+	logger.SetOutput(&buf)
+	logger.With(WithString("k", "v")).Info("child")
+	if strings.Contains(buf.String(), "\x1b[") {
+		t.Errorf("Expected no colors after SetOutput, got %q", buf.String())
+	}
+}
@@ -0,0 +1,118 @@
+package log
+
+import "context"
+
+type (
+	loggerKey struct{}
+	fieldsKey struct{}
+)
+
+// NewContext returns a copy of ctx that carries l.
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext returns the Logger carried by ctx, or the default logger if
+// there is none.
+func FromContext(ctx context.Context) Logger {
+	if l, ok := ctx.Value(loggerKey{}).(Logger); ok {
+		return l
+	}
+	return std
+}
+
+// ContextWithFields returns a copy of ctx that carries fields in addition to
+// the ones already stored in ctx. The *Ctx logging methods add these fields
+// to every entry logged with the returned context.
+func ContextWithFields(ctx context.Context, fields ...Data) context.Context {
+	existing := ContextFields(ctx)
+	merged := make([]Data, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// ContextFields returns the fields carried by ctx. The returned slice must not be modified.
+func ContextFields(ctx context.Context) []Data {
+	fields, _ := ctx.Value(fieldsKey{}).([]Data)
+	return fields
+}
+
+// withContextFields returns the fields carried by ctx followed by fields.
+func withContextFields(ctx context.Context, fields []Data) []Data {
+	stored := ContextFields(ctx)
+	if len(stored) == 0 {
+		return fields
+	}
+	merged := make([]Data, 0, len(stored)+len(fields))
+	merged = append(merged, stored...)
+	return append(merged, fields...)
+}
+
+// PanicCtx logs a message with the fields carried by ctx at PanicLevel and then
+// panics with a *PanicError.
+func PanicCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, PanicLevel, msg, fields)
+	panic(newPanicError(PanicLevel, msg, withContextFields(ctx, fields)))
+}
+
+// FatalCtx logs a message with the fields carried by ctx at FatalLevel, then
+// flushes the output, runs the exit hooks and calls the exit function.
+func FatalCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, FatalLevel, msg, fields)
+	std.terminate()
+}
+
+// ErrorCtx logs a message with the fields carried by ctx at ErrorLevel.
+func ErrorCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, ErrorLevel, msg, fields)
+}
+
+// WarnCtx logs a message with the fields carried by ctx at WarnLevel.
+func WarnCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, WarnLevel, msg, fields)
+}
+
+// InfoCtx logs a message with the fields carried by ctx at InfoLevel.
+func InfoCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, InfoLevel, msg, fields)
+}
+
+// DebugCtx logs a message with the fields carried by ctx at DebugLevel.
+func DebugCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, DebugLevel, msg, fields)
+}
+
+// PanicCtx logs a message with the fields carried by ctx at PanicLevel and then
+// panics with a *PanicError.
+func (l *logger) PanicCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, PanicLevel, msg, fields)
+	panic(newPanicError(PanicLevel, msg, withContextFields(ctx, fields)))
+}
+
+// FatalCtx logs a message with the fields carried by ctx at FatalLevel, then
+// flushes the output, runs the exit hooks and calls the exit function.
+func (l *logger) FatalCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, FatalLevel, msg, fields)
+	l.terminate()
+}
+
+// ErrorCtx logs a message with the fields carried by ctx at ErrorLevel.
+func (l *logger) ErrorCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, ErrorLevel, msg, fields)
+}
+
+// WarnCtx logs a message with the fields carried by ctx at WarnLevel.
+func (l *logger) WarnCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, WarnLevel, msg, fields)
+}
+
+// InfoCtx logs a message with the fields carried by ctx at InfoLevel.
+func (l *logger) InfoCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, InfoLevel, msg, fields)
+}
+
+// DebugCtx logs a message with the fields carried by ctx at DebugLevel.
+func (l *logger) DebugCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, DebugLevel, msg, fields)
+}
@@ -0,0 +1,126 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	if FromContext(context.Background()) != Logger(std) {
+		t.Error("Expected the default logger when the context carries none")
+	}
+
+	logger := New(InfoLevel, &bytes.Buffer{})
+	ctx := NewContext(context.Background(), logger)
+	if FromContext(ctx) != logger {
+		t.Error("Expected the logger stored in the context")
+	}
+}
+
+func TestContextWithFields(t *testing.T) {
+	parent := ContextWithFields(context.Background(), WithString("request_id", "abc"))
+	child := ContextWithFields(parent, WithString("tenant", "acme"))
+	sibling := ContextWithFields(parent, WithString("tenant", "other"))
+
+	if fields := ContextFields(parent); len(fields) != 1 {
+		t.Errorf("Parent context should keep 1 field, got %d", len(fields))
+	}
+	if fields := ContextFields(child); len(fields) != 2 || fields[1].String != "acme" {
+		t.Errorf("Unexpected child fields: %+v", fields)
+	}
+	if fields := ContextFields(sibling); len(fields) != 2 || fields[1].String != "other" {
+		t.Errorf("Unexpected sibling fields: %+v", fields)
+	}
+	if fields := ContextFields(context.Background()); fields != nil {
+		t.Errorf("Expected no fields, got %+v", fields)
+	}
+}
+
+func TestCtxLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(DebugLevel, &buf, OptionIncludeFileInfo(false), OptionExitFunc(func(int) {})).With(WithString("service", "api"))
+	ctx := ContextWithFields(context.Background(), WithString("request_id", "abc"))
+
+	tests := []struct {
+		name     string
+		logFn    func()
+		expected string
+	}{
+		{
+			"DebugCtx",
+			func() { logger.DebugCtx(ctx, "debug msg") },
+			`"level":"DEBUG","msg":"debug msg","service":"api","request_id":"abc"}`,
+		},
+		{
+			"InfoCtx with fields",
+			func() { logger.InfoCtx(ctx, "user logged in", WithString("user", "john")) },
+			`"level":"INFO","msg":"user logged in","service":"api","request_id":"abc","user":"john"}`,
+		},
+		{
+			"WarnCtx with untyped fields",
+			func() { logger.WarnCtx(ctx, "slow", WithAny("ms", 250)) },
+			`"level":"WARN","msg":"slow","service":"api","request_id":"abc","ms":250}`,
+		},
+		{
+			"ErrorCtx without context fields",
+			func() { logger.ErrorCtx(context.Background(), "failed") },
+			`"level":"ERROR","msg":"failed","service":"api"}`,
+		},
+		{
+			"FatalCtx without message",
+			func() { logger.FatalCtx(ctx, "", WithInt("code", 1)) },
+			`"level":"FATAL","service":"api","request_id":"abc","code":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.logFn()
+			if !strings.HasSuffix(buf.String(), tt.expected+"\n") {
+				t.Errorf("Expected suffix %s in output: %s", tt.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("PanicCtx", func(t *testing.T) {
+		buf.Reset()
+		defer func() {
+			if pe, ok := recover().(*PanicError); !ok || pe.Message != "boom" || len(pe.Fields) != 1 {
+				t.Errorf("Expected *PanicError with message and context fields, got %#v", pe)
+			}
+			if !strings.Contains(buf.String(), `"msg":"boom","service":"api","request_id":"abc"`) {
+				t.Errorf("Expected entry before panic: %s", buf.String())
+			}
+		}()
+		logger.PanicCtx(ctx, "boom")
+	})
+}
+
+func TestCtxDefaultLogger(t *testing.T) {
+	buf, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	ctx := ContextWithFields(context.Background(), WithString("request_id", "abc"))
+	DebugCtx(ctx, "filtered")
+	if buf.Len() > 0 {
+		t.Errorf("DebugCtx should be filtered at Info level: %s", buf.String())
+	}
+
+	InfoCtx(ctx, "hello")
+	if !strings.Contains(buf.String(), `"caller":"log_context_test.go:`) ||
+		!strings.Contains(buf.String(), `"msg":"hello","request_id":"abc"}`) {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestCtxFilteredDoesNotAllocate(t *testing.T) {
+	logger := New(InfoLevel, &bytes.Buffer{})
+	ctx := ContextWithFields(context.Background(), WithString("request_id", "abc"))
+
+	if allocs := testing.AllocsPerRun(100, func() { logger.DebugCtx(ctx, "filtered") }); allocs != 0 {
+		t.Errorf("Expected no allocations for a filtered entry, got %v", allocs)
+	}
+}
@@ -0,0 +1,167 @@
+package log
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nszilard/log/internal"
+)
+
+// Entry is a log entry as passed to encoders.
+type Entry struct {
+	Level      Level
+	Time       time.Time
+	LoggerName string // Dotted name of the logger, empty for root loggers
+	File       string // File of the call that logged the entry, empty without file information
+	Line       int
+	Message    string // Empty for structured entries logged without a message
+	Fields     []Data
+	Stack      string // Stack trace of the logging goroutine, empty unless enabled with OptionStacktrace
+
+	// Fields bound to the logger with With, encoded once by EncodeFields of
+	// the encoder the entry is passed to.
+	Context []byte
+}
+
+// Encoder turns entries into bytes. Encoders must be safe for concurrent use.
+type Encoder interface {
+	// EncodeEntry appends the encoded entry, followed by a newline, to buf
+	// and returns the extended buffer. buf comes from a pool and must not be
+	// retained.
+	EncodeEntry(buf []byte, e *Entry) []byte
+	// EncodeFields appends fields the way EncodeEntry writes them after the
+	// message. It is used to encode the fields bound with With once.
+	EncodeFields(buf []byte, fields []Data) []byte
+}
+
+// Sink is the destination of encoded entries. Sinks must be safe for
+// concurrent use.
+type Sink interface {
+	io.Writer
+	// Sync flushes buffered entries.
+	Sync() error
+	// Close flushes and releases the sink.
+	Close() error
+}
+
+// writerSink is a Sink writing to an io.Writer.
+type writerSink struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewWriterSink returns a Sink that serializes writes to w. Sync calls the
+// Sync or Flush method of w and Close its Close method, if w has one.
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{out: w}
+}
+
+// Write writes p to the underlying writer.
+func (s *writerSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out.Write(p)
+}
+
+// Sync flushes the underlying writer if it supports flushing through a Sync or Flush method.
+func (s *writerSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch out := s.out.(type) {
+	case interface{ Sync() error }:
+		return out.Sync()
+	case interface{ Flush() error }:
+		return out.Flush()
+	}
+	return nil
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (s *writerSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.out.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// output returns the underlying writer.
+func (s *writerSink) output() io.Writer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.out
+}
+
+// setOutput changes the underlying writer.
+func (s *writerSink) setOutput(out io.Writer) {
+	s.mu.Lock()
+	s.out = out
+	s.mu.Unlock()
+}
+
+// Core writes entries that pass its level to a sink, using an encoder.
+type Core struct {
+	level   *AtomicLevel
+	encoder Encoder
+	sink    Sink
+}
+
+// NewCore creates a Core that encodes entries with encoder and writes them to
+// sink. A nil level is only allowed for cores given to OptionCore, which then
+// use the level of the logger.
+func NewCore(level *AtomicLevel, encoder Encoder, sink Sink) *Core {
+	return &Core{level: level, encoder: encoder, sink: sink}
+}
+
+// Enabled reports whether entries at level pass the level of the core.
+func (c *Core) Enabled(level Level) bool {
+	return c.level.Enabled(level)
+}
+
+// Write encodes e into a pooled buffer and writes it to the sink. It does
+// not check the level; callers check Enabled first.
+func (c *Core) Write(e *Entry) error {
+	buf := internal.GetBuf(200 + len(e.Message) + len(e.Context) + len(e.Fields)*50 + len(e.Stack))
+	*buf = c.encoder.EncodeEntry((*buf)[:0], e)
+	_, err := c.sink.Write(*buf)
+	internal.PutBuf(buf)
+	return err
+}
+
+// Sync flushes the sink.
+func (c *Core) Sync() error {
+	return c.sink.Sync()
+}
+
+// OptionCore makes the logger write every entry, plain and structured, with
+// core. The logger takes its level from the core, or the core the level of the
+// logger if it has none; options configuring the built-in encoders have no
+// effect on it.
+func OptionCore(core *Core) Option {
+	return func(l *logger) {
+		if core.level == nil {
+			core = NewCore(l.level.level.Load(), core.encoder, core.sink)
+		}
+		l.cores.Store(&coreSet{core: core, structuredCore: core})
+		l.encoderConfig = nil
+		l.level.level.Store(core.level)
+	}
+}
+
+// entryPool recycles entries, which escape to the heap through the encoder.
+var entryPool = sync.Pool{New: func() any { return &Entry{} }}
+
+// getEntry returns an empty entry from the pool.
+func getEntry() *Entry {
+	return entryPool.Get().(*Entry)
+}
+
+// putEntry clears e and returns it to the pool.
+func putEntry(e *Entry) {
+	*e = Entry{}
+	entryPool.Put(e)
+}
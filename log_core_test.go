@@ -0,0 +1,159 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// upperEncoder is a minimal Encoder writing "LEVEL message key=value".
+type upperEncoder struct{}
+
+func (upperEncoder) EncodeEntry(buf []byte, e *Entry) []byte {
+	buf = append(buf, e.Level.String()...)
+	buf = append(buf, ' ')
+	buf = append(buf, strings.ToUpper(e.Message)...)
+	buf = append(buf, e.Context...)
+	buf = upperEncoder{}.EncodeFields(buf, e.Fields)
+	return append(buf, '\n')
+}
+
+func (upperEncoder) EncodeFields(buf []byte, fields []Data) []byte {
+	for _, f := range fields {
+		buf = append(buf, ' ')
+		buf = append(buf, f.Key...)
+		buf = append(buf, '=')
+		buf = append(buf, f.String...)
+	}
+	return buf
+}
+
+// recordingSink is a Sink recording its calls.
+type recordingSink struct {
+	bytes.Buffer
+	synced, closed bool
+	err            error
+}
+
+func (s *recordingSink) Write(p []byte) (int, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	return s.Buffer.Write(p)
+}
+
+func (s *recordingSink) Sync() error {
+	s.synced = true
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestCore(t *testing.T) {
+	sink := &recordingSink{}
+	core := NewCore(NewAtomicLevel(WarnLevel), upperEncoder{}, sink)
+
+	if core.Enabled(InfoLevel) || !core.Enabled(ErrorLevel) {
+		t.Error("Core should filter entries below its level")
+	}
+
+	if err := core.Write(&Entry{Level: ErrorLevel, Message: "disk full", Fields: []Data{WithString("disk", "sda")}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if want := "ERROR DISK FULL disk=sda\n"; sink.String() != want {
+		t.Errorf("Expected %q, got %q", want, sink.String())
+	}
+
+	if err := core.Sync(); err != nil || !sink.synced {
+		t.Errorf("Expected sink to be synced, err: %v", err)
+	}
+}
+
+func TestOptionCore(t *testing.T) {
+	sink := &recordingSink{}
+	level := NewAtomicLevel(InfoLevel)
+	logger := New(DebugLevel, nil, OptionCore(NewCore(level, upperEncoder{}, sink)))
+
+	logger.Debug("filtered by the core level")
+	logger.Info("plain")
+	logger.With(WithString("request_id", "abc")).InfoS(WithString("user", "john"))
+	logger.Named("db").WarnCtx(t.Context(), "slow query")
+
+	want := "INFO PLAIN\nINFO  request_id=abc user=john\nWARN SLOW QUERY\n"
+	if sink.String() != want {
+		t.Errorf("Expected %q, got %q", want, sink.String())
+	}
+
+	level.SetLevel(ErrorLevel)
+	logger.Warn("filtered")
+	if sink.String() != want {
+		t.Errorf("Logger should follow the level of the core: %q", sink.String())
+	}
+
+	logger.SetOutput(&bytes.Buffer{})
+	logger.Error("still in sink")
+	if !strings.HasSuffix(sink.String(), "ERROR STILL IN SINK\n") {
+		t.Errorf("SetOutput should not replace a custom sink: %q", sink.String())
+	}
+
+	if err := logger.Sync(); err != nil || !sink.synced {
+		t.Errorf("Expected Sync to sync the sink, err: %v", err)
+	}
+}
+
+func TestOptionCoreWithoutLevel(t *testing.T) {
+	sink := &recordingSink{}
+	logger := New(WarnLevel, nil, OptionCore(NewCore(nil, upperEncoder{}, sink)))
+
+	logger.Info("filtered by the logger level")
+	logger.Warn("written")
+	logger.SetLevel(InfoLevel)
+	logger.Info("written after SetLevel")
+
+	if want := "WARN WRITTEN\nINFO WRITTEN AFTER SETLEVEL\n"; sink.String() != want {
+		t.Errorf("Expected %q, got %q", want, sink.String())
+	}
+}
+
+func TestCoreWriteError(t *testing.T) {
+	var errBuf bytes.Buffer
+	sink := &recordingSink{err: errors.New("disk full")}
+	logger := New(InfoLevel, nil, OptionErrorOutput(&errBuf), OptionCore(NewCore(NewAtomicLevel(InfoLevel), upperEncoder{}, sink)))
+
+	logger.Info("lost")
+	if want := "log: write failed: disk full\n"; errBuf.String() != want {
+		t.Errorf("Expected %q, got %q", want, errBuf.String())
+	}
+}
+
+type closeRecorder struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestWriterSink(t *testing.T) {
+	out := &closeRecorder{}
+	sink := NewWriterSink(out)
+
+	if _, err := sink.Write([]byte("entry\n")); err != nil || out.String() != "entry\n" {
+		t.Errorf("Unexpected write result %q, err: %v", out.String(), err)
+	}
+	if err := sink.Sync(); err != nil {
+		t.Errorf("Sync without Sync or Flush method should succeed, got %v", err)
+	}
+	if err := sink.Close(); err != nil || !out.closed {
+		t.Errorf("Expected writer to be closed, err: %v", err)
+	}
+	if err := NewWriterSink(&bytes.Buffer{}).Close(); err != nil {
+		t.Errorf("Close without Close method should succeed, got %v", err)
+	}
+}
@@ -0,0 +1,259 @@
+package log
+
+import (
+	"time"
+
+	"github.com/nszilard/log/internal"
+)
+
+// EncoderConfig configures the built-in encoders.
+type EncoderConfig struct {
+	// TimeFormat is the layout of entry timestamps, following the rules of
+	// time.Time.Format. An empty layout selects RFC 3339 with millisecond
+	// precision.
+	TimeFormat string
+	// TimeEncoding selects how entry timestamps and WithTime fields are
+	// written. The zero value writes them with their layouts.
+	TimeEncoding TimeEncoding
+	// TimeLocation is the location times are converted to before they are
+	// written with a layout. When nil, entry timestamps are written in UTC
+	// and WithTime fields in their own location.
+	TimeLocation *time.Location
+	// FieldTimeFormat is the layout of WithTime fields. An empty layout
+	// selects time.RFC3339Nano.
+	FieldTimeFormat string
+	// DurationEncoding selects how WithDuration fields are written.
+	DurationEncoding DurationEncoding
+	// Keys names the header elements of JSON and logfmt entries.
+	Keys HeaderKeys
+}
+
+// headerTime returns the encoder of entry timestamps.
+func (cfg *EncoderConfig) headerTime() timeEncoder {
+	te := timeEncoder{encoding: cfg.TimeEncoding, layout: cfg.TimeFormat, location: cfg.TimeLocation}
+	if te.layout == "" {
+		te.layout = internal.TimestampFormat
+	}
+	if te.location == nil {
+		te.location = time.UTC
+	}
+	return te
+}
+
+// fieldEncoding returns the settings fields are written with, using
+// durations for DurationDefault.
+func (cfg *EncoderConfig) fieldEncoding(durations DurationEncoding) *fieldEncoding {
+	te := timeEncoder{encoding: cfg.TimeEncoding, layout: cfg.FieldTimeFormat, location: cfg.TimeLocation}
+	if te.encoding == TimeOmitted {
+		te.encoding = TimeLayout
+	}
+	if te.layout == "" {
+		te.layout = time.RFC3339Nano
+	}
+	fe := &fieldEncoding{time: te, duration: cfg.DurationEncoding}
+	if fe.duration == DurationDefault {
+		fe.duration = durations
+	}
+	return fe
+}
+
+// fieldEncoding holds the settings the built-in encoders write fields with.
+type fieldEncoding struct {
+	time     timeEncoder
+	duration DurationEncoding
+}
+
+// defaultFieldEncoding writes fields the way the default text encoder does.
+var defaultFieldEncoding = (&EncoderConfig{}).fieldEncoding(DurationString)
+
+// textEncoder writes entries as a text header followed by the message and
+// key=value fields.
+type textEncoder struct {
+	time   timeEncoder
+	fields *fieldEncoding
+}
+
+// NewTextEncoder returns an Encoder producing lines like
+//
+//	2025-09-25T13:20:18.524Z [INFO] (main.go:12) ▶ user logged in user=john
+func NewTextEncoder(cfg EncoderConfig) Encoder {
+	return &textEncoder{time: cfg.headerTime(), fields: cfg.fieldEncoding(DurationString)}
+}
+
+// EncodeEntry appends the text line of e to buf.
+func (enc *textEncoder) EncodeEntry(buf []byte, e *Entry) []byte {
+	var ts [64]byte
+	buf = internal.AppendTextHeader(buf, enc.timestamp(ts[:0], e.Time), e.Level.String(), e.LoggerName, e.File, e.Line, e.File != "")
+	buf = append(buf, e.Message...)
+	if len(e.Context) > 0 || len(e.Fields) > 0 {
+		// Drop a trailing newline of the message, or the space after the
+		// header when there is no message, as the fields start with a space
+		if n := len(buf); buf[n-1] == '\n' || e.Message == "" {
+			buf = buf[:n-1]
+		}
+		buf = append(buf, e.Context...)
+		buf = enc.EncodeFields(buf, e.Fields)
+	}
+	if buf[len(buf)-1] != '\n' {
+		buf = append(buf, '\n')
+	}
+	return appendStack(buf, e.Stack)
+}
+
+// EncodeFields appends fields as " key=value" pairs to buf.
+func (enc *textEncoder) EncodeFields(buf []byte, fields []Data) []byte {
+	return appendFlatFields(buf, fields, textStyle, enc.fields)
+}
+
+// appendStack appends stack, if not empty, as lines indented by a tab.
+func appendStack(buf []byte, stack string) []byte {
+	if stack == "" {
+		return buf
+	}
+	buf = append(buf, '\t')
+	for i := 0; i < len(stack); i++ {
+		buf = append(buf, stack[i])
+		if stack[i] == '\n' {
+			buf = append(buf, '\t')
+		}
+	}
+	return append(buf, '\n')
+}
+
+// timestamp appends the timestamp of an entry to buf, or nothing if timestamps are omitted.
+func (enc *textEncoder) timestamp(buf []byte, t time.Time) []byte {
+	if enc.time.omitted() {
+		return buf
+	}
+	return enc.time.appendTime(buf, t)
+}
+
+// jsonEncoder writes entries as JSON objects.
+type jsonEncoder struct {
+	time      timeEncoder
+	fields    *fieldEncoding
+	keys      internal.HeaderKeys // Rendered as `,"key":`
+	levelName func(Level) string
+}
+
+// NewJSONEncoder returns an Encoder producing lines like
+//
+//	{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","caller":"main.go:12","msg":"user logged in","user":"john"}
+func NewJSONEncoder(cfg EncoderConfig) Encoder {
+	return &jsonEncoder{
+		time:      cfg.headerTime(),
+		fields:    cfg.fieldEncoding(DurationNanos),
+		keys:      internal.JSONHeaderKeys(cfg.Keys.withDefaults(jsonDefaultKeys)),
+		levelName: cfg.Keys.levelNamer(),
+	}
+}
+
+// EncodeEntry appends the JSON object of e to buf.
+func (enc *jsonEncoder) EncodeEntry(buf []byte, e *Entry) []byte {
+	start := len(buf)
+	var ts [64]byte
+	internal.BuildStructuredHeader(&buf, &enc.keys, enc.timestamp(ts[:0], e.Time), enc.levelName(e.Level), e.LoggerName, e.File != "", e.File, e.Line)
+	if e.Message != "" && enc.keys.Message != "" {
+		buf = append(buf, enc.keys.Message...)
+		buf = internal.AppendQuoted(buf, e.Message)
+	}
+	buf = append(buf, e.Context...)
+
+	// Entries with untyped fields write nil errors as null, like the
+	// values of WithAny; otherwise they are written as empty strings.
+	untyped := !allTyped(e.Fields)
+	fields := toInternal(e.Fields)
+	for i := range fields {
+		buf = appendJSONField(buf, &fields[i], untyped, enc.fields)
+	}
+
+	if e.Stack != "" && enc.keys.Stacktrace != "" {
+		buf = append(buf, enc.keys.Stacktrace...)
+		buf = internal.AppendQuoted(buf, e.Stack)
+	}
+
+	// Every member starts with a comma, the first one opens the object
+	if len(buf) == start {
+		return append(buf, "{}\n"...)
+	}
+	buf[start] = '{'
+	return append(buf, "}\n"...)
+}
+
+// EncodeFields appends fields as JSON members, each preceded by a comma, to buf.
+func (enc *jsonEncoder) EncodeFields(buf []byte, fields []Data) []byte {
+	fs := toInternal(fields)
+	for i := range fs {
+		buf = appendJSONField(buf, &fs[i], false, enc.fields)
+	}
+	return buf
+}
+
+// timestamp appends the timestamp of an entry to buf as a JSON value, or
+// nothing if timestamps are omitted.
+func (enc *jsonEncoder) timestamp(buf []byte, t time.Time) []byte {
+	if enc.time.omitted() {
+		return buf
+	}
+	return enc.time.appendJSON(buf, t)
+}
+
+// logfmtEncoder writes entries as logfmt key=value pairs.
+type logfmtEncoder struct {
+	time      timeEncoder
+	fields    *fieldEncoding
+	keys      internal.HeaderKeys // Rendered as " key="
+	levelName func(Level) string
+}
+
+// NewLogfmtEncoder returns an Encoder producing logfmt lines like
+//
+//	ts=2025-09-25T13:20:18.524Z level=info caller=main.go:12 msg="user logged in" user=john
+//
+// Values are quoted only when needed, and keys have characters that logfmt
+// does not allow replaced by underscores.
+func NewLogfmtEncoder(cfg EncoderConfig) Encoder {
+	return &logfmtEncoder{
+		time:      cfg.headerTime(),
+		fields:    cfg.fieldEncoding(DurationString),
+		keys:      internal.LogfmtHeaderKeys(cfg.Keys.withDefaults(logfmtDefaultKeys)),
+		levelName: cfg.Keys.levelNamer(),
+	}
+}
+
+// EncodeEntry appends the logfmt line of e to buf.
+func (enc *logfmtEncoder) EncodeEntry(buf []byte, e *Entry) []byte {
+	start := len(buf)
+	var ts [64]byte
+	buf = internal.AppendLogfmtHeader(buf, &enc.keys, enc.timestamp(ts[:0], e.Time), enc.levelName(e.Level), e.LoggerName, e.File, e.Line, e.File != "")
+	if e.Message != "" && enc.keys.Message != "" {
+		buf = internal.AppendLogfmtHeaderKey(buf, start, enc.keys.Message)
+		buf = internal.AppendLogfmtString(buf, e.Message)
+	}
+	header := len(buf)
+	buf = append(buf, e.Context...)
+	buf = enc.EncodeFields(buf, e.Fields)
+	if header == start && len(buf) > start {
+		// Without a header, drop the space the fields start with
+		buf = append(buf[:start], buf[start+1:]...)
+	}
+	if e.Stack != "" && enc.keys.Stacktrace != "" {
+		buf = internal.AppendLogfmtHeaderKey(buf, start, enc.keys.Stacktrace)
+		buf = internal.AppendLogfmtString(buf, e.Stack)
+	}
+	return append(buf, '\n')
+}
+
+// EncodeFields appends fields as " key=value" pairs to buf.
+func (enc *logfmtEncoder) EncodeFields(buf []byte, fields []Data) []byte {
+	return appendFlatFields(buf, fields, logfmtStyle, enc.fields)
+}
+
+// timestamp appends the timestamp of an entry to buf as a logfmt value, or
+// nothing if timestamps are omitted.
+func (enc *logfmtEncoder) timestamp(buf []byte, t time.Time) []byte {
+	if enc.time.omitted() {
+		return buf
+	}
+	return enc.time.appendLogfmt(buf, t)
+}
@@ -0,0 +1,230 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEncoders(t *testing.T) {
+	entry := &Entry{
+		Level:      WarnLevel,
+		Time:       time.Date(2025, 9, 25, 15, 20, 18, 524000000, time.FixedZone("CEST", 2*60*60)),
+		LoggerName: "db.pool",
+		File:       "pool.go",
+		Line:       42,
+		Message:    "slow query",
+		Fields:     []Data{WithDuration("elapsed", 1500*time.Millisecond), WithError("err", errors.New("timeout"))},
+	}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		context  []Data
+		expected string
+	}{
+		{
+			"Text",
+			NewTextEncoder(EncoderConfig{}),
+			[]Data{WithString("service", "api")},
+			"2025-09-25T13:20:18.524Z [WARN] [db.pool] (pool.go:42) ▶ slow query service=api elapsed=1.5s err=timeout\n",
+		},
+		{
+			"JSON",
+			NewJSONEncoder(EncoderConfig{}),
+			[]Data{WithString("service", "api")},
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"WARN","logger":"db.pool","caller":"pool.go:42",` +
+				`"msg":"slow query","service":"api","elapsed":1500000000,"err":"timeout"}` + "\n",
+		},
+		{
+			"Logfmt",
+			NewLogfmtEncoder(EncoderConfig{}),
+			[]Data{WithString("service", "api")},
+			`ts=2025-09-25T13:20:18.524Z level=warn logger=db.pool caller=pool.go:42 msg="slow query" service=api elapsed=1.5s err=timeout` + "\n",
+		},
+		{
+			"TimeFormat",
+			NewTextEncoder(EncoderConfig{TimeFormat: time.Kitchen}),
+			nil,
+			"1:20PM [WARN] [db.pool] (pool.go:42) ▶ slow query elapsed=1.5s err=timeout\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := *entry
+			e.Context = tt.encoder.EncodeFields(nil, tt.context)
+			if got := string(tt.encoder.EncodeEntry(nil, &e)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEncoderWithoutCaller(t *testing.T) {
+	entry := &Entry{Level: InfoLevel, Time: fixedClock(), Fields: []Data{WithInt("count", 3)}}
+
+	if got, want := string(NewJSONEncoder(EncoderConfig{}).EncodeEntry(nil, entry)), `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","count":3}`+"\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if got, want := string(NewTextEncoder(EncoderConfig{}).EncodeEntry(nil, entry)), "2025-09-25T13:20:18.524Z [INFO] ▶ count=3\n"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestLogfmtEncoderQuoting(t *testing.T) {
+	enc := NewLogfmtEncoder(EncoderConfig{})
+	tests := []struct {
+		name     string
+		field    Data
+		expected string
+	}{
+		{"Plain", WithString("user", "john"), " user=john"},
+		{"Empty", WithString("user", ""), ` user=""`},
+		{"Space", WithString("query", "SELECT 1"), ` query="SELECT 1"`},
+		{"Equals", WithString("expr", "a=b"), ` expr="a=b"`},
+		{"Quote", WithString("say", `"hi"`), ` say="\"hi\""`},
+		{"Newline", WithString("trace", "line1\nline2"), ` trace="line1\nline2"`},
+		{"Backslash", WithString("path", `C:\tmp`), ` path=C:\tmp`},
+		{"Unicode", WithString("city", "Zürich"), " city=Zürich"},
+		{"InvalidUTF8", WithString("raw", "a\xffb"), ` raw="a\xffb"`},
+		{"KeyWithSpace", WithString("user name", "john"), " user_name=john"},
+		{"EmptyKey", WithString("", "x"), " _=x"},
+		{"Int", WithInt("user_id", 12345), " user_id=12345"},
+		{"Float", WithFloat("ratio", 0.25), " ratio=0.25"},
+		{"Bool", WithBool("ok", true), " ok=true"},
+		{"NilError", WithError("err", nil), ` err=""`},
+		{"Time", WithTime("at", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)), " at=2023-01-01T12:00:00Z"},
+		{"AnyNil", WithAny("v", nil), " v=null"},
+		{"AnyUint", WithAny("v", uint64(7)), " v=7"},
+		{"AnySlice", WithAny("tags", []string{"a", "b c"}), ` tags="[\"a\",\"b c\"]"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(enc.EncodeFields(nil, []Data{tt.field})); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLogfmtEncoderLogger(t *testing.T) {
+	var buf bytes.Buffer
+	core := NewCore(NewAtomicLevel(InfoLevel), NewLogfmtEncoder(EncoderConfig{}), NewWriterSink(&buf))
+	logger := New(InfoLevel, nil, OptionCore(core), OptionClock(fixedClock), OptionIncludeFileInfo(false))
+
+	logger.Infof("user %s logged in", "john")
+	logger.With(WithInt("user_id", 12345)).InfoS(WithBool("admin", false))
+
+	want := `ts=2025-09-25T13:20:18.524Z level=info msg="user john logged in"` + "\n" +
+		`ts=2025-09-25T13:20:18.524Z level=info user_id=12345 admin=false` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestJSONEncoderEscaping(t *testing.T) {
+	enc := NewJSONEncoder(EncoderConfig{})
+	tests := []struct {
+		name     string
+		field    Data
+		expected string
+	}{
+		{"ASCII", WithString("user", "john"), `,"user":"john"`},
+		{"Quote", WithString("say", `"hi"`), `,"say":"\"hi\""`},
+		{"Backslash", WithString("path", `C:\tmp`), `,"path":"C:\\tmp"`},
+		{"Newline", WithString("trace", "a\nb\r\tc"), `,"trace":"a\nb\r\tc"`},
+		{"Control", WithString("raw", "\x00\x1f\b\f"), `,"raw":"\u0000\u001f\b\f"`},
+		{"Unicode", WithString("city", "Zürich ✓"), `,"city":"Zürich ✓"`},
+		{"InvalidUTF8", WithString("raw", "a\xff\xfeb"), `,"raw":"a\ufffd\ufffdb"`},
+		{"TruncatedRune", WithString("raw", "\xe2\x9c"), `,"raw":"\ufffd\ufffd"`},
+		{"LineSeparators", WithString("js", "\u2028\u2029"), `,"js":"\u2028\u2029"`},
+		{"Key", WithInt("a\"b\n", 1), `,"a\"b\n":1`},
+		{"NaN", WithFloat("f", math.NaN()), `,"f":"NaN"`},
+		{"PosInf", WithFloat("f", math.Inf(1)), `,"f":"+Inf"`},
+		{"NegInf", WithFloat("f", math.Inf(-1)), `,"f":"-Inf"`},
+		{"AnyNaN", WithAny("f", float32(math.NaN())), `,"f":"NaN"`},
+		{"AnyString", WithAny("s", "x\"y"), `,"s":"x\"y"`},
+		{"Error", WithError("err", errors.New("open \"a\": denied")), `,"err":"open \"a\": denied"`},
+		{"RawJSON", WithAny("raw", []byte("{\n  \"a\": 1\n}")), `,"raw":{"a":1}`},
+		{"RawText", WithAny("raw", []byte("not\njson")), `,"raw":"not\njson"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := string(enc.EncodeFields(nil, []Data{tt.field}))
+			if got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+			if !json.Valid([]byte("{" + got[1:] + "}")) {
+				t.Errorf("Invalid JSON: %s", got)
+			}
+		})
+	}
+}
+
+func TestJSONEncoderEscapesHeader(t *testing.T) {
+	e := &Entry{Level: InfoLevel, Time: fixedClock(), LoggerName: `a"b`, File: `my "file".go`, Line: 1, Message: "multi\nline"}
+	got := string(NewJSONEncoder(EncoderConfig{TimeFormat: `"2006"`}).EncodeEntry(nil, e))
+	want := `{"timestamp":"\"2025\"","level":"INFO","logger":"a\"b","caller":"my \"file\".go:1","msg":"multi\nline"}` + "\n"
+	if got != want {
+		t.Errorf("Expected %s, got %s", want, got)
+	}
+}
+
+func FuzzJSONEncoder(f *testing.F) {
+	for _, seed := range []string{
+		"", "plain ascii", `"quoted"`, `back\slash`, "new\nline", "\r\t\b\f", "\x00\x01\x1f\x7f",
+		"Zürich", "日本語", "emoji 🎉", "\u2028\u2029", "\xff", "\xc3", "\xe2\x9c", "\xed\xa0\x80",
+		"a\xffb\xfe", "</script>", "\\u0000", `{"a":1}`,
+	} {
+		f.Add(seed, 1.5)
+	}
+	f.Add("nan", math.NaN())
+	f.Add("inf", math.Inf(1))
+	f.Add("-inf", math.Inf(-1))
+
+	enc := NewJSONEncoder(EncoderConfig{})
+	f.Fuzz(func(t *testing.T, s string, fl float64) {
+		e := &Entry{
+			Level:      InfoLevel,
+			Time:       fixedClock(),
+			LoggerName: s,
+			File:       s,
+			Message:    "m" + s,
+			Fields: []Data{
+				WithString("k_"+s, s),
+				WithError("err", errors.New(s)),
+				WithFloat("float", fl),
+				WithAny("any", s),
+				WithAny("bytes", []byte(s)),
+			},
+		}
+		e.Context = enc.EncodeFields(nil, []Data{WithString("ctx", s)})
+		out := enc.EncodeEntry(nil, e)
+
+		if !json.Valid(out) {
+			t.Fatalf("Invalid JSON for %q: %s", s, out)
+		}
+		if i := bytes.IndexByte(out, '\n'); i != len(out)-1 {
+			t.Fatalf("Entry for %q is not a single line: %q", s, out)
+		}
+
+		var decoded map[string]any
+		if err := json.Unmarshal(out, &decoded); err != nil {
+			t.Fatalf("Cannot decode %s: %v", out, err)
+		}
+		// Strings decode to what encoding/json makes of them, which replaces
+		// invalid UTF-8 the same way
+		var want string
+		marshalled, _ := json.Marshal(s)
+		_ = json.Unmarshal(marshalled, &want)
+		if decoded["msg"] != "m"+want || decoded["ctx"] != want || (s != "" && decoded["logger"] != want) {
+			t.Errorf("Round trip of %q failed: %s", s, out)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package log
+
+import "sync"
+
+var (
+	exitHooksMu sync.Mutex
+	exitHooks   []func()
+)
+
+// RegisterExitHook adds fn to the functions run by the Fatal methods of every
+// logger before the process exits. Hooks run in the order they were
+// registered; a hook that panics does not prevent the others from running.
+func RegisterExitHook(fn func()) {
+	exitHooksMu.Lock()
+	exitHooks = append(exitHooks, fn)
+	exitHooksMu.Unlock()
+}
+
+// SetExitFunc sets the function the default logger calls to terminate the
+// process after a Fatal entry. It defaults to os.Exit and is safe to call
+// while other goroutines are logging.
+func SetExitFunc(exit func(code int)) {
+	std.exit.Store(&exit)
+}
+
+// OptionExitFunc sets the function the logger calls to terminate the process
+// after a Fatal entry. It defaults to os.Exit; tests can replace it to observe
+// fatal entries without exiting.
+func OptionExitFunc(exit func(code int)) Option {
+	return func(l *logger) {
+		l.exit.Store(&exit)
+	}
+}
+
+// terminate flushes the output, runs the exit hooks and calls the exit
+// function with status code 1.
+func (l *logger) terminate() {
+	_ = l.Sync()
+	runExitHooks()
+	(*l.exit.Load())(1)
+}
+
+// runExitHooks runs the registered exit hooks in order.
+func runExitHooks() {
+	exitHooksMu.Lock()
+	hooks := append([]func(){}, exitHooks...)
+	exitHooksMu.Unlock()
+
+	for _, hook := range hooks {
+		func() {
+			defer func() { _ = recover() }()
+			hook()
+		}()
+	}
+}
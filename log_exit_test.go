@@ -0,0 +1,162 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type syncRecorder struct {
+	bytes.Buffer
+	calls *[]string
+}
+
+func (s *syncRecorder) Sync() error {
+	*s.calls = append(*s.calls, "sync")
+	return nil
+}
+
+type flushRecorder struct {
+	bytes.Buffer
+	flushed bool
+}
+
+func (f *flushRecorder) Flush() error {
+	f.flushed = true
+	return nil
+}
+
+func withExitHooks(t *testing.T, hooks ...func()) {
+	t.Helper()
+	exitHooksMu.Lock()
+	old := exitHooks
+	exitHooks = nil
+	exitHooksMu.Unlock()
+	t.Cleanup(func() {
+		exitHooksMu.Lock()
+		exitHooks = old
+		exitHooksMu.Unlock()
+	})
+	for _, hook := range hooks {
+		RegisterExitHook(hook)
+	}
+}
+
+func TestFatalTerminates(t *testing.T) {
+	var calls []string
+	withExitHooks(t,
+		func() { calls = append(calls, "hook1") },
+		func() { panic("broken hook") },
+		func() { calls = append(calls, "hook3") },
+	)
+
+	out := &syncRecorder{calls: &calls}
+	logger := New(InfoLevel, out, OptionExitFunc(func(code int) {
+		if !strings.Contains(out.String(), "[FATAL]") && !strings.Contains(out.String(), `"level":"FATAL"`) {
+			t.Errorf("Entry should be written before exiting: %q", out.String())
+		}
+		calls = append(calls, "exit")
+		if code != 1 {
+			t.Errorf("Expected exit code 1, got %d", code)
+		}
+	}))
+
+	tests := []struct {
+		name  string
+		logFn func()
+	}{
+		{"Fatal", func() { logger.Fatal("fatal msg") }},
+		{"Fatalf", func() { logger.Fatalf("fatal: %s", "error") }},
+		{"FatalS", func() { logger.FatalS(WithString("fatal", "error")) }},
+		{"FatalCtx", func() { logger.FatalCtx(context.Background(), "fatal msg") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			out.Reset()
+			tt.logFn()
+			expected := "sync,hook1,hook3,exit"
+			if got := strings.Join(calls, ","); got != expected {
+				t.Errorf("Expected calls %s, got %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestFatalFilteredStillExits(t *testing.T) {
+	withExitHooks(t)
+
+	var buf bytes.Buffer
+	exited := false
+	logger := New(PanicLevel, &buf, OptionExitFunc(func(int) { exited = true }))
+	logger.Fatal("filtered")
+	if buf.Len() > 0 || !exited {
+		t.Errorf("Expected exit without output, got exited=%v output=%q", exited, buf.String())
+	}
+}
+
+func TestLogFatalLevelDoesNotExit(t *testing.T) {
+	withExitHooks(t, func() { t.Error("Exit hooks should not run") })
+
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionExitFunc(func(int) { t.Error("Log should not exit") }))
+	logger.Log(FatalLevel, "fatal msg")
+	logger.Logf(FatalLevel, "fatal %s", "msg")
+	logger.LogS(FatalLevel, WithString("fatal", "msg"))
+	if strings.Count(buf.String(), "FATAL") != 3 {
+		t.Errorf("Expected 3 FATAL entries: %s", buf.String())
+	}
+}
+
+func TestDefaultLoggerExitFunc(t *testing.T) {
+	withExitHooks(t)
+	buf, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	code := 0
+	SetExitFunc(func(c int) { code = c })
+	FatalS(WithString("fatal", "msg"))
+	if code != 1 || !strings.Contains(buf.String(), `"fatal":"msg"`) {
+		t.Errorf("Expected fatal entry and exit code 1, got %d: %s", code, buf.String())
+	}
+}
+
+func TestConcurrentExitFunc(t *testing.T) {
+	withExitHooks(t)
+	_, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	var exits atomic.Int32
+	SetExitFunc(func(int) { exits.Add(1) })
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range 100 {
+			SetExitFunc(func(int) { exits.Add(1) })
+		}
+	})
+	wg.Go(func() {
+		for range 100 {
+			Fatal("fatal msg")
+		}
+	})
+	wg.Wait()
+	if exits.Load() != 100 {
+		t.Errorf("Expected 100 exits, got %d", exits.Load())
+	}
+}
+
+func TestSync(t *testing.T) {
+	out := &flushRecorder{}
+	logger := New(InfoLevel, out)
+	if err := logger.Sync(); err != nil || !out.flushed {
+		t.Errorf("Expected output to be flushed, got flushed=%v err=%v", out.flushed, err)
+	}
+
+	if err := New(InfoLevel, &bytes.Buffer{}).Sync(); err != nil {
+		t.Errorf("Sync on a plain writer should succeed, got %v", err)
+	}
+}
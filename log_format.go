@@ -0,0 +1,146 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format selects the built-in encoders a logger writes entries with.
+type Format uint8
+
+const (
+	// FormatMixed writes plain and formatted entries as text and structured
+	// entries as JSON. It is the default.
+	FormatMixed Format = iota
+	// FormatText writes every entry as text, with fields as key=value pairs.
+	FormatText
+	// FormatJSON writes every entry as a JSON object, with the message in "msg".
+	FormatJSON
+	// FormatLogfmt writes every entry as logfmt.
+	FormatLogfmt
+	// FormatConsole writes every entry in the human-friendly console form,
+	// colored when the output is a terminal (see ColorEnabled).
+	FormatConsole
+)
+
+var formatNames = [...]string{"mixed", "text", "json", "logfmt", "console"}
+
+// String returns the lowercase name of the format.
+func (f Format) String() string {
+	if int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return fmt.Sprintf("Format(%d)", f)
+}
+
+// ParseFormat returns the format with the given name, matched without regard to case.
+func ParseFormat(name string) (Format, error) {
+	for i, n := range formatNames {
+		if strings.EqualFold(strings.TrimSpace(name), n) {
+			return Format(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown format %q", name)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseFormat.
+func (f *Format) UnmarshalText(text []byte) error {
+	format, err := ParseFormat(string(text))
+	if err != nil {
+		return err
+	}
+	*f = format
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f Format) MarshalText() ([]byte, error) {
+	if int(f) >= len(formatNames) {
+		return nil, fmt.Errorf("invalid format %d", f)
+	}
+	return []byte(formatNames[f]), nil
+}
+
+// SetFormat sets the format of the default logger. It is safe to call while
+// other goroutines are logging; child loggers created earlier keep the
+// previous format.
+func SetFormat(format Format) {
+	std.swapCores(func(*coreSet) Format { return format })
+}
+
+// OptionFormat sets the format the logger writes entries in. It has no
+// effect on loggers given a Core with OptionCore.
+func OptionFormat(format Format) Option {
+	return func(l *logger) {
+		l.swapCores(func(*coreSet) Format { return format })
+	}
+}
+
+// buildCores recreates the cores of a logger with the built-in encoders for
+// its format and encoder configuration. It does nothing for loggers given a Core.
+func (l *logger) buildCores() {
+	l.swapCores(func(current *coreSet) Format { return current.format })
+}
+
+// swapCores publishes cores built for the format chosen from the current
+// cores, trying again if they are replaced in the meantime. It does nothing
+// for loggers given a Core.
+func (l *logger) swapCores(format func(current *coreSet) Format) {
+	if l.encoderConfig == nil {
+		return
+	}
+	for {
+		current := l.cores.Load()
+		next := l.newCores(format(current), current.core.level, current.core.sink)
+		if l.cores.CompareAndSwap(current, next) {
+			return
+		}
+	}
+}
+
+// newCores returns cores writing to sink with the built-in encoders for
+// format, with the fields bound to the logger encoded for them.
+func (l *logger) newCores(format Format, level *AtomicLevel, sink Sink) *coreSet {
+	cfg := *l.encoderConfig
+	var enc Encoder
+	switch format {
+	case FormatText:
+		enc = NewTextEncoder(cfg)
+	case FormatJSON:
+		enc = NewJSONEncoder(cfg)
+	case FormatLogfmt:
+		enc = NewLogfmtEncoder(cfg)
+	case FormatConsole:
+		var out io.Writer
+		if ws, ok := sink.(*writerSink); ok {
+			out = ws.output()
+		}
+		enc = NewConsoleEncoder(cfg, ColorEnabled(out))
+	}
+
+	cores := &coreSet{format: format}
+	if enc != nil {
+		cores.core = NewCore(level, enc, sink)
+		cores.structuredCore = cores.core
+	} else {
+		cores.core = NewCore(level, NewTextEncoder(cfg), sink)
+		cores.structuredCore = NewCore(level, NewJSONEncoder(cfg), sink)
+	}
+	cores.encodeContext(l.context[:len(l.context)-len(l.lazyContext)])
+	return cores
+}
+
+// encodeContext appends fields to the bound fields encoded for each core.
+// The encoded fields of other sets sharing their memory are left intact.
+func (cs *coreSet) encodeContext(fields []Data) {
+	if len(fields) == 0 {
+		return
+	}
+	cs.coreContext = cs.core.encoder.EncodeFields(cs.coreContext[:len(cs.coreContext):len(cs.coreContext)], fields)
+	if cs.structuredCore == cs.core {
+		cs.structuredContext = cs.coreContext
+	} else {
+		cs.structuredContext = cs.structuredCore.encoder.EncodeFields(cs.structuredContext[:len(cs.structuredContext):len(cs.structuredContext)], fields)
+	}
+}
@@ -0,0 +1,137 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestOptionFormat(t *testing.T) {
+	tests := []struct {
+		format     Format
+		plain      string
+		structured string
+	}{
+		{
+			FormatMixed,
+			"2025-09-25T13:20:18.524Z [INFO] ▶ x service=api\n",
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","service":"api","user":"john"}` + "\n",
+		},
+		{
+			FormatJSON,
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","msg":"x","service":"api"}` + "\n",
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","service":"api","user":"john"}` + "\n",
+		},
+		{
+			FormatText,
+			"2025-09-25T13:20:18.524Z [INFO] ▶ x service=api\n",
+			"2025-09-25T13:20:18.524Z [INFO] ▶ service=api user=john\n",
+		},
+		{
+			FormatLogfmt,
+			"ts=2025-09-25T13:20:18.524Z level=info msg=x service=api\n",
+			"ts=2025-09-25T13:20:18.524Z level=info service=api user=john\n",
+		},
+		{
+			FormatConsole,
+			"2025-09-25T13:20:18.524Z INFO  ▶ x                                        service=api\n",
+			"2025-09-25T13:20:18.524Z INFO  ▶ service=api user=john\n",
+		},
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	for _, tt := range tests {
+		t.Run(tt.format.String(), func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(InfoLevel, &buf, OptionFormat(tt.format), OptionClock(fixedClock), OptionIncludeFileInfo(false)).
+				With(WithString("service", "api"))
+
+			logger.Info("x")
+			if buf.String() != tt.plain {
+				t.Errorf("Expected plain entry %q, got %q", tt.plain, buf.String())
+			}
+
+			buf.Reset()
+			logger.InfoS(WithString("user", "john"))
+			if buf.String() != tt.structured {
+				t.Errorf("Expected structured entry %q, got %q", tt.structured, buf.String())
+			}
+		})
+	}
+}
+
+func TestOptionFormatCaller(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionFormat(FormatJSON), OptionClock(fixedClock))
+
+	logger.Info("x")
+	if !strings.HasPrefix(buf.String(), `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","caller":"log_format_test.go:`) ||
+		!strings.HasSuffix(buf.String(), `","msg":"x"}`+"\n") {
+		t.Errorf("Unexpected output: %q", buf.String())
+	}
+}
+
+func TestOptionFormatKeepsTimeFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionTimeFormat("15:04"), OptionFormat(FormatLogfmt), OptionClock(fixedClock), OptionIncludeFileInfo(false))
+
+	logger.Info("x")
+	if want := "ts=13:20 level=info msg=x\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	buf, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+	defer SetFormat(FormatMixed)
+
+	SetFormat(FormatJSON)
+	Infof("user %s", "john")
+	if !strings.Contains(buf.String(), `"level":"INFO"`) || !strings.HasSuffix(buf.String(), `"msg":"user john"}`+"\n") {
+		t.Errorf("Expected JSON output, got %q", buf.String())
+	}
+}
+
+func TestSetFormatConcurrent(t *testing.T) {
+	_, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 100 {
+			SetFormat(Format(i % len(formatNames)))
+			SetOutput(io.Discard)
+		}
+	})
+	wg.Go(func() {
+		for range 100 {
+			Info("plain")
+			InfoS(WithString("k", "v"))
+			_ = With(WithInt("n", 1))
+		}
+	})
+	wg.Wait()
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, format := range []Format{FormatMixed, FormatText, FormatJSON, FormatLogfmt, FormatConsole} {
+		text, err := format.MarshalText()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		var parsed Format
+		if err := parsed.UnmarshalText(bytes.ToUpper(text)); err != nil || parsed != format {
+			t.Errorf("Expected %v, got %v (err: %v)", format, parsed, err)
+		}
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected error for unknown format")
+	}
+	if _, err := Format(42).MarshalText(); err == nil || Format(42).String() != "Format(42)" {
+		t.Error("Expected error for invalid format")
+	}
+}
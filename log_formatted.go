@@ -4,46 +4,112 @@ import (
 	"github.com/nszilard/log/internal"
 )
 
-// Panicf logs a formatted message at PanicLevel and then panics.
+// Logf logs a formatted message at the given level, which may be a registered
+// level. Unlike Panicf and Fatalf, it never panics or exits, whatever the level.
+func Logf(level Level, format string, v ...any) {
+	if std.enabled(level) {
+		std.write(level, internal.Sprintf(format, v...))
+	}
+}
+
+// Panicf logs a formatted message at PanicLevel and then panics with a *PanicError.
 func Panicf(format string, v ...any) {
 	msg := internal.Sprintf(format, v...)
-	if PanicLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(PanicLevel.String(), msg, std.includeFileInfo)
+	if std.enabled(PanicLevel) {
+		std.write(PanicLevel, msg)
 	}
-	panic(msg)
+	panic(newPanicError(PanicLevel, msg, nil))
 }
 
-// Fatalf logs a formatted message at FatalLevel.
+// Fatalf logs a formatted message at FatalLevel, then flushes the output, runs
+// the exit hooks and calls the exit function. Use Logf(FatalLevel, ...) to log
+// at FatalLevel without exiting.
 func Fatalf(format string, v ...any) {
-	if FatalLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(FatalLevel.String(), internal.Sprintf(format, v...), std.includeFileInfo)
+	if std.enabled(FatalLevel) {
+		std.write(FatalLevel, internal.Sprintf(format, v...))
 	}
+	std.terminate()
 }
 
 // Errorf logs a formatted message at ErrorLevel.
 func Errorf(format string, v ...any) {
-	if ErrorLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(ErrorLevel.String(), internal.Sprintf(format, v...), std.includeFileInfo)
+	if std.enabled(ErrorLevel) {
+		std.write(ErrorLevel, internal.Sprintf(format, v...))
 	}
 }
 
 // Warnf logs a formatted message at WarnLevel.
 func Warnf(format string, v ...any) {
-	if WarnLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(WarnLevel.String(), internal.Sprintf(format, v...), std.includeFileInfo)
+	if std.enabled(WarnLevel) {
+		std.write(WarnLevel, internal.Sprintf(format, v...))
 	}
 }
 
 // Infof logs a formatted message at InfoLevel.
 func Infof(format string, v ...any) {
-	if InfoLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(InfoLevel.String(), internal.Sprintf(format, v...), std.includeFileInfo)
+	if std.enabled(InfoLevel) {
+		std.write(InfoLevel, internal.Sprintf(format, v...))
 	}
 }
 
 // Debugf logs a formatted message at DebugLevel.
 func Debugf(format string, v ...any) {
-	if DebugLevel <= std.currentLevel {
-		std.internal.LogWithFileInfo(DebugLevel.String(), internal.Sprintf(format, v...), std.includeFileInfo)
+	if std.enabled(DebugLevel) {
+		std.write(DebugLevel, internal.Sprintf(format, v...))
+	}
+}
+
+// Logf logs a formatted message at the given level, which may be a registered
+// level. Unlike Panicf and Fatalf, it never panics or exits, whatever the level.
+func (l *logger) Logf(level Level, format string, v ...any) {
+	if l.enabled(level) {
+		l.write(level, internal.Sprintf(format, v...))
+	}
+}
+
+// Panicf logs a formatted message at PanicLevel and then panics with a *PanicError.
+func (l *logger) Panicf(format string, v ...any) {
+	msg := internal.Sprintf(format, v...)
+	if l.enabled(PanicLevel) {
+		l.write(PanicLevel, msg)
+	}
+	panic(newPanicError(PanicLevel, msg, nil))
+}
+
+// Fatalf logs a formatted message at FatalLevel, then flushes the output, runs
+// the exit hooks and calls the exit function. Use Logf(FatalLevel, ...) to log
+// at FatalLevel without exiting.
+func (l *logger) Fatalf(format string, v ...any) {
+	if l.enabled(FatalLevel) {
+		l.write(FatalLevel, internal.Sprintf(format, v...))
+	}
+	l.terminate()
+}
+
+// Errorf logs a formatted message at ErrorLevel.
+func (l *logger) Errorf(format string, v ...any) {
+	if l.enabled(ErrorLevel) {
+		l.write(ErrorLevel, internal.Sprintf(format, v...))
+	}
+}
+
+// Warnf logs a formatted message at WarnLevel.
+func (l *logger) Warnf(format string, v ...any) {
+	if l.enabled(WarnLevel) {
+		l.write(WarnLevel, internal.Sprintf(format, v...))
+	}
+}
+
+// Infof logs a formatted message at InfoLevel.
+func (l *logger) Infof(format string, v ...any) {
+	if l.enabled(InfoLevel) {
+		l.write(InfoLevel, internal.Sprintf(format, v...))
+	}
+}
+
+// Debugf logs a formatted message at DebugLevel.
+func (l *logger) Debugf(format string, v ...any) {
+	if l.enabled(DebugLevel) {
+		l.write(DebugLevel, internal.Sprintf(format, v...))
 	}
 }
@@ -0,0 +1,180 @@
+package log
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Hook is called for entries that pass the level check, before they are written.
+type Hook interface {
+	// Levels returns the levels the hook is called for. An empty result
+	// means every level, including registered ones.
+	Levels() []Level
+	// Fire is called with a view of the entry. An error is reported to the
+	// logger's error output and does not prevent the entry from being written.
+	Fire(e *EntryView) error
+}
+
+// EntryView is the read-only view of an entry passed to hooks. Hooks can add
+// fields to the entry with AddFields but cannot change it otherwise. An
+// EntryView must not be retained after Fire returns.
+type EntryView struct {
+	entry   *Entry
+	context []Data
+	merged  []Data
+	added   []Data
+}
+
+// Level returns the level of the entry.
+func (e *EntryView) Level() Level {
+	return e.entry.Level
+}
+
+// Time returns the timestamp of the entry.
+func (e *EntryView) Time() time.Time {
+	return e.entry.Time
+}
+
+// Caller returns the file and line of the call that logged the entry, or an
+// empty file if the logger does not include file information.
+func (e *EntryView) Caller() (file string, line int) {
+	return e.entry.File, e.entry.Line
+}
+
+// LoggerName returns the dotted name of the logger, empty for root loggers.
+func (e *EntryView) LoggerName() string {
+	return e.entry.LoggerName
+}
+
+// Message returns the message of the entry. Structured entries logged
+// without a message return an empty string.
+func (e *EntryView) Message() string {
+	return e.entry.Message
+}
+
+// Fields returns the fields bound to the logger with With, followed by the
+// fields of the entry. Fields added by hooks are not included. The returned
+// slice must not be modified.
+func (e *EntryView) Fields() []Data {
+	if len(e.context) == 0 {
+		return e.entry.Fields
+	}
+	if e.merged == nil {
+		e.merged = make([]Data, 0, len(e.context)+len(e.entry.Fields))
+		e.merged = append(e.merged, e.context...)
+		e.merged = append(e.merged, e.entry.Fields...)
+	}
+	return e.merged
+}
+
+// AddFields adds fields to the entry. They are written after the entry's own
+// fields; hooks that run later do not see them.
+func (e *EntryView) AddFields(fields ...Data) {
+	e.added = append(e.added, fields...)
+}
+
+// hookFunc is a Hook backed by a function.
+type hookFunc struct {
+	fn     func(e *EntryView) error
+	levels []Level
+}
+
+// NewHook returns a Hook that calls fn for entries at the given levels, or at
+// every level if none are given.
+func NewHook(fn func(e *EntryView) error, levels ...Level) Hook {
+	return &hookFunc{fn: fn, levels: levels}
+}
+
+// Levels returns the levels the hook was created with.
+func (h *hookFunc) Levels() []Level {
+	return h.levels
+}
+
+// Fire calls the hook function.
+func (h *hookFunc) Fire(e *EntryView) error {
+	return h.fn(e)
+}
+
+// hookTable holds the hooks of a logger indexed by level. Tables are never
+// modified once stored; adding a hook stores a copy.
+type hookTable [256][]Hook
+
+// hooksMu serializes hook registration.
+var hooksMu sync.Mutex
+
+// AddHook adds hook to the default logger.
+func AddHook(hook Hook) {
+	std.AddHook(hook)
+}
+
+// AddHook adds hook to the logger. Hooks are called in the order they were
+// added, for entries at the levels they report. Child loggers created
+// afterwards inherit the hook; existing children are not affected.
+func (l *logger) AddHook(hook Hook) {
+	addHook(l.hooks, hook)
+}
+
+// OptionHooks adds hooks to the logger.
+func OptionHooks(hooks ...Hook) Option {
+	return func(l *logger) {
+		for _, hook := range hooks {
+			addHook(l.hooks, hook)
+		}
+	}
+}
+
+// OptionErrorOutput sets where the logger reports its own errors, such as
+// failing hooks. It defaults to os.Stderr.
+func OptionErrorOutput(w io.Writer) Option {
+	return func(l *logger) {
+		l.errorOutput = w
+	}
+}
+
+// addHook stores a copy of the table in p with hook added at its levels.
+func addHook(p *atomic.Pointer[hookTable], hook Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	table := &hookTable{}
+	if current := p.Load(); current != nil {
+		*table = *current
+	}
+	add := func(level Level) {
+		hooks := table[level]
+		table[level] = append(hooks[:len(hooks):len(hooks)], hook)
+	}
+	if levels := hook.Levels(); len(levels) > 0 {
+		for _, level := range levels {
+			add(level)
+		}
+	} else {
+		for level := range table {
+			add(Level(level))
+		}
+	}
+	p.Store(table)
+}
+
+// fire calls hooks with e in order. Errors and panics are reported to the
+// error output and do not stop the remaining hooks.
+func (l *logger) fire(hooks []Hook, e *EntryView) {
+	for _, hook := range hooks {
+		if err := callHook(hook, e); err != nil {
+			fmt.Fprintf(l.errorOutput, "log: hook failed for %s entry: %v\n", e.Level(), err)
+		}
+	}
+}
+
+// callHook calls hook, turning a panic into an error.
+func callHook(hook Hook, e *EntryView) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return hook.Fire(e)
+}
@@ -0,0 +1,217 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHookEntryView(t *testing.T) {
+	var buf bytes.Buffer
+	var (
+		level   Level
+		at      time.Time
+		name    string
+		message string
+		file    string
+		line    int
+		fields  []Data
+	)
+	hook := NewHook(func(e *EntryView) error {
+		level, at, name, message = e.Level(), e.Time(), e.LoggerName(), e.Message()
+		file, line = e.Caller()
+		fields = append(fields, e.Fields()...)
+		return nil
+	})
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionHooks(hook))
+
+	logger.Named("api").With(WithString("service", "users")).InfoS(WithInt("status", 200))
+	if level != InfoLevel || !at.Equal(fixedClock()) || name != "api" || message != "" {
+		t.Errorf("Unexpected entry view: %v %v %q %q", level, at, name, message)
+	}
+	if file != "log_hook_test.go" || line == 0 {
+		t.Errorf("Expected caller in this file, got %s:%d", file, line)
+	}
+	if len(fields) != 2 || fields[0].Key != "service" || fields[1].Key != "status" {
+		t.Errorf("Expected bound and entry fields, got %+v", fields)
+	}
+	if !strings.Contains(buf.String(), `"caller":"`+file+`:`+strconv.Itoa(line)+`"`) {
+		t.Errorf("Expected entry to be written with the hook's caller: %s", buf.String())
+	}
+}
+
+func TestHookAddFields(t *testing.T) {
+	var buf bytes.Buffer
+	hook := NewHook(func(e *EntryView) error {
+		e.AddFields(WithString("host", "web-1"))
+		return nil
+	})
+	logger := New(InfoLevel, &buf, OptionHooks(hook), OptionIncludeFileInfo(false), OptionClock(fixedClock))
+
+	logger.Info("started")
+	if want := "2025-09-25T13:20:18.524Z [INFO] ▶ started host=web-1\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	logger.InfoS(WithInt("port", 8080))
+	if want := `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","port":8080,"host":"web-1"}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	logger.InfoS(WithAny("ids", []int{1, 2}))
+	if !strings.HasSuffix(buf.String(), `"ids":[1,2],"host":"web-1"}`+"\n") {
+		t.Errorf("Expected added field after untyped fields: %s", buf.String())
+	}
+}
+
+func TestHookLevels(t *testing.T) {
+	var buf bytes.Buffer
+	var errorCount int
+	logger := New(DebugLevel, &buf)
+	logger.AddHook(NewHook(func(*EntryView) error {
+		errorCount++
+		return nil
+	}, ErrorLevel, FatalLevel))
+
+	logger.Info("info")
+	logger.Warnf("warn %d", 1)
+	logger.Error("error")
+	logger.ErrorS(WithString("error", "timeout"))
+	logger.Log(FatalLevel, "fatal")
+
+	if errorCount != 3 {
+		t.Errorf("Expected hook to run for 3 entries, got %d", errorCount)
+	}
+}
+
+func TestHookFilteredEntries(t *testing.T) {
+	var buf bytes.Buffer
+	called := false
+	logger := New(WarnLevel, &buf, OptionHooks(NewHook(func(*EntryView) error {
+		called = true
+		return nil
+	})))
+
+	logger.Info("filtered")
+	logger.DebugS(WithString("filtered", "yes"))
+	if called {
+		t.Error("Hooks should not run for entries below the level")
+	}
+}
+
+func TestHookErrors(t *testing.T) {
+	var buf, errBuf bytes.Buffer
+	var order []string
+	logger := New(InfoLevel, &buf, OptionErrorOutput(&errBuf), OptionHooks(
+		NewHook(func(*EntryView) error {
+			order = append(order, "first")
+			return errors.New("alerting unavailable")
+		}),
+		NewHook(func(*EntryView) error {
+			order = append(order, "second")
+			panic("boom")
+		}),
+		NewHook(func(*EntryView) error {
+			order = append(order, "third")
+			return nil
+		}),
+	))
+
+	logger.Warn("disk almost full")
+	if !strings.Contains(buf.String(), "disk almost full") {
+		t.Errorf("Entry should be written despite hook errors: %s", buf.String())
+	}
+	if strings.Join(order, ",") != "first,second,third" {
+		t.Errorf("Expected hooks to run in order, got %v", order)
+	}
+	errs := errBuf.String()
+	if !strings.Contains(errs, "log: hook failed for WARN entry: alerting unavailable\n") ||
+		!strings.Contains(errs, "log: hook failed for WARN entry: panic: boom\n") {
+		t.Errorf("Expected hook errors to be reported: %q", errs)
+	}
+}
+
+func TestHookDefaultLogger(t *testing.T) {
+	buf, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	AddHook(NewHook(func(e *EntryView) error {
+		e.AddFields(WithString("env", "test"))
+		return nil
+	}, WarnLevel))
+	Info("plain")
+	WarnS(WithInt("retries", 3))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || strings.Contains(lines[0], "env=test") || !strings.HasSuffix(lines[1], `"retries":3,"env":"test"}`) {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestHookChildLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	var names []string
+	logger := New(InfoLevel, &buf)
+	before := logger.Named("before")
+	logger.AddHook(NewHook(func(e *EntryView) error {
+		names = append(names, e.LoggerName())
+		return nil
+	}))
+	after := logger.Named("after")
+	after.Named("child").AddHook(NewHook(func(*EntryView) error {
+		names = append(names, "child-only")
+		return nil
+	}))
+
+	before.Info("x")
+	after.Info("x")
+	after.Named("child").Info("x")
+	if strings.Join(names, ",") != "after,after.child" {
+		t.Errorf("Unexpected hook calls: %v", names)
+	}
+}
+
+func TestHookSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	var msg string
+	logger := New(InfoLevel, &buf, OptionHooks(NewHook(func(e *EntryView) error {
+		msg = e.Message()
+		e.AddFields(WithString("source", "slog"))
+		return nil
+	})))
+
+	slog.New(NewSlogHandler(logger)).Info("from slog", "attempt", 1)
+	if msg != "from slog" {
+		t.Errorf("Expected hook to see slog message, got %q", msg)
+	}
+	if !strings.HasSuffix(buf.String(), `"msg":"from slog","attempt":1,"source":"slog"}`+"\n") {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestHookSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger := New(InfoLevel, nil, OptionSlogSink(sink), OptionClock(func() time.Time { return time.Time{} }), OptionHooks(NewHook(func(e *EntryView) error {
+		e.AddFields(WithBool("hooked", true))
+		return nil
+	})))
+
+	logger.Info("forwarded")
+	if want := `{"level":"INFO","msg":"forwarded","hooked":true}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
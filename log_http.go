@@ -0,0 +1,123 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// levelPayload is the JSON body accepted and returned by the level handlers.
+type levelPayload struct {
+	Logger string `json:"logger,omitempty"`
+	Level  string `json:"level"`
+}
+
+// levelError is the JSON body returned when a request fails.
+type levelError struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP exposes the level over HTTP. GET returns the current level as
+// {"level":"INFO"}; PUT and POST change it from a body of the same form.
+func (a *AtomicLevel) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, levelPayload{Level: a.String()})
+	case http.MethodPut, http.MethodPost:
+		payload, ok := decodeLevelPayload(w, r)
+		if !ok {
+			return
+		}
+		level, err := ParseLevel(payload.Level)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, levelError{Error: err.Error()})
+			return
+		}
+		a.SetLevel(level)
+		writeJSON(w, http.StatusOK, levelPayload{Level: a.String()})
+	default:
+		w.Header().Set("Allow", "GET, PUT, POST")
+		writeJSON(w, http.StatusMethodNotAllowed, levelError{Error: "method not allowed"})
+	}
+}
+
+// NewLevelHandler returns an http.Handler that inspects and changes the levels
+// of l and of the loggers named below it. The logger name, relative to l, is
+// read from the "logger" query parameter or the "logger" body field; an empty
+// name refers to l itself.
+//
+// GET returns {"logger":"db","level":"DEBUG"} with the level in effect for the
+// name, inherited or not. PUT and POST take a body of the same form and set
+// the level for the name, and by inheritance for the names below it. They
+// respond with 404 Not Found for names no logger has been created with.
+//
+// Only loggers created by this package support level changes; for any other
+// Logger the handler responds with 501 Not Implemented.
+func NewLevelHandler(l Logger) http.Handler {
+	lg, _ := l.(*logger)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if lg == nil {
+			writeJSON(w, http.StatusNotImplemented, levelError{Error: "logger does not support level changes"})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			name := r.URL.Query().Get("logger")
+			node, _ := lg.level.lookup(name)
+			writeJSON(w, http.StatusOK, levelPayload{Logger: name, Level: node.effective().String()})
+		case http.MethodPut, http.MethodPost:
+			payload, ok := decodeLevelPayload(w, r)
+			if !ok {
+				return
+			}
+			if payload.Logger == "" {
+				payload.Logger = r.URL.Query().Get("logger")
+			}
+			level, err := ParseLevel(payload.Level)
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, levelError{Error: err.Error()})
+				return
+			}
+			// Names come from clients, so only loggers that exist are
+			// changed; creating nodes for any name would grow the tree
+			node, ok := lg.level.lookup(payload.Logger)
+			if !ok {
+				writeJSON(w, http.StatusNotFound, levelError{Error: "unknown logger " + strconv.Quote(payload.Logger)})
+				return
+			}
+			node.set(level)
+			writeJSON(w, http.StatusOK, levelPayload{Logger: payload.Logger, Level: node.effective().String()})
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			writeJSON(w, http.StatusMethodNotAllowed, levelError{Error: "method not allowed"})
+		}
+	})
+}
+
+// maxLevelBodySize is the largest request body the level handlers read.
+const maxLevelBodySize = 4 << 10
+
+// decodeLevelPayload reads the request body, writing an error response if it
+// is invalid or too large.
+func decodeLevelPayload(w http.ResponseWriter, r *http.Request) (levelPayload, bool) {
+	var payload levelPayload
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxLevelBodySize)).Decode(&payload)
+	if tooLarge := (*http.MaxBytesError)(nil); errors.As(err, &tooLarge) {
+		writeJSON(w, http.StatusRequestEntityTooLarge, levelError{Error: "request body too large"})
+		return payload, false
+	}
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, levelError{Error: "invalid request body: " + err.Error()})
+		return payload, false
+	}
+	return payload, true
+}
+
+// writeJSON writes v as a JSON response with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
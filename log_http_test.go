@@ -0,0 +1,106 @@
+package log
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serveLevel(t *testing.T, h http.Handler, method, target, body string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", ct)
+	}
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func TestAtomicLevelServeHTTP(t *testing.T) {
+	level := NewAtomicLevel(InfoLevel)
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+		expectedLevel  Level
+	}{
+		{"GET", http.MethodGet, "", http.StatusOK, `{"level":"INFO"}`, InfoLevel},
+		{"PUT", http.MethodPut, `{"level":"debug"}`, http.StatusOK, `{"level":"DEBUG"}`, DebugLevel},
+		{"POST", http.MethodPost, `{"level":"WARN"}`, http.StatusOK, `{"level":"WARN"}`, WarnLevel},
+		{"Unknown level", http.MethodPut, `{"level":"loud"}`, http.StatusBadRequest, `{"error":"unknown level \"loud\""}`, WarnLevel},
+		{"Invalid body", http.MethodPut, `{`, http.StatusBadRequest, `{"error":"invalid request body: unexpected EOF"}`, WarnLevel},
+		{"DELETE", http.MethodDelete, "", http.StatusMethodNotAllowed, `{"error":"method not allowed"}`, WarnLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := serveLevel(t, level, tt.method, "/level", tt.body)
+			if status != tt.expectedStatus || body != tt.expectedBody {
+				t.Errorf("Expected %d %s, got %d %s", tt.expectedStatus, tt.expectedBody, status, body)
+			}
+			if level.Level() != tt.expectedLevel {
+				t.Errorf("Expected level %s, got %s", tt.expectedLevel, level)
+			}
+		})
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	var buf bytes.Buffer
+	root := New(InfoLevel, &buf)
+	pool := root.Named("db.pool")
+	handler := NewLevelHandler(root)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"GET root", http.MethodGet, "/level", "", http.StatusOK, `{"level":"INFO"}`},
+		{"GET unknown name", http.MethodGet, "/level?logger=http.server", "", http.StatusOK, `{"logger":"http.server","level":"INFO"}`},
+		{"PUT named", http.MethodPut, "/level", `{"logger":"db","level":"debug"}`, http.StatusOK, `{"logger":"db","level":"DEBUG"}`},
+		{"GET inherited", http.MethodGet, "/level?logger=db.pool", "", http.StatusOK, `{"logger":"db.pool","level":"DEBUG"}`},
+		{"POST with query name", http.MethodPost, "/level?logger=db.pool", `{"level":"error"}`, http.StatusOK, `{"logger":"db.pool","level":"ERROR"}`},
+		{"PUT root", http.MethodPut, "/level", `{"level":"warn"}`, http.StatusOK, `{"level":"WARN"}`},
+		{"GET overridden", http.MethodGet, "/level?logger=db", "", http.StatusOK, `{"logger":"db","level":"DEBUG"}`},
+		{"Unknown level", http.MethodPut, "/level", `{"level":"loud"}`, http.StatusBadRequest, `{"error":"unknown level \"loud\""}`},
+		{"PUT unknown name", http.MethodPut, "/level", `{"logger":"http.server","level":"debug"}`, http.StatusNotFound, `{"error":"unknown logger \"http.server\""}`},
+		{"Body too large", http.MethodPut, "/level", `{"level":"debug","logger":"` + strings.Repeat("a", 5000) + `"}`, http.StatusRequestEntityTooLarge, `{"error":"request body too large"}`},
+		{"PATCH", http.MethodPatch, "/level", "", http.StatusMethodNotAllowed, `{"error":"method not allowed"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := serveLevel(t, handler, tt.method, tt.target, tt.body)
+			if status != tt.expectedStatus || body != tt.expectedBody {
+				t.Errorf("Expected %d %s, got %d %s", tt.expectedStatus, tt.expectedBody, status, body)
+			}
+		})
+	}
+
+	if root.(*logger).level.find("http") != nil {
+		t.Error("Setting the level of an unknown name should not create it")
+	}
+
+	buf.Reset()
+	pool.Warn("filtered")
+	root.Named("db").Debug("written")
+	if strings.Contains(buf.String(), "filtered") || !strings.Contains(buf.String(), "written") {
+		t.Errorf("Levels changed over HTTP were not applied: %s", buf.String())
+	}
+}
+
+func TestLevelHandlerNoop(t *testing.T) {
+	status, body := serveLevel(t, NewLevelHandler(NoopLogger{}), http.MethodGet, "/level", "")
+	if status != http.StatusNotImplemented || body != `{"error":"logger does not support level changes"}` {
+		t.Errorf("Unexpected response: %d %s", status, body)
+	}
+}
@@ -1,6 +1,9 @@
 package log
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 // FieldType represents the type of a structured logging field.
 type FieldType uint8
@@ -14,6 +17,15 @@ const (
 	ErrorType
 	DurationType
 	TimeType
+	ObjectType
+	ArrayType
+	UintType
+	Float32Type
+	BytesType
+	StringerType
+	ComplexType
+	NilType
+	LazyType
 )
 
 // Data represents a key-value pair for structured logging with type-specific storage
@@ -32,40 +44,70 @@ type Data struct {
 // Logger provides leveled and structured logging.
 // All methods are safe for concurrent use.
 type Logger interface {
-	// SetLevel sets the minimum level for logging.
+	// SetLevel sets the minimum level for logging. Loggers created with With
+	// share the level of their parent, so on either it changes both.
 	SetLevel(level Level)
-	// SetOutput sets the output destination for logs.
+	// SetOutput sets the output destination for logs. The output is shared
+	// by a logger and all loggers created from it with With or Named, so it
+	// changes for all of them.
 	SetOutput(out io.Writer)
-	// SetIncludeFileInfo sets whether to include file and line information in logs.
+	// SetIncludeFileInfo sets whether to include file and line information
+	// in logs. Unlike the level and output, it only affects this logger.
 	SetIncludeFileInfo(include bool)
+	// Sync flushes any buffered output.
+	Sync() error
+	// AddHook adds a hook called before entries at its levels are written.
+	AddHook(hook Hook)
+
+	// With returns a child logger that adds fields to every entry.
+	With(fields ...Data) Logger
+	// Named returns a child logger with a dotted name and its own level.
+	Named(name string) Logger
+
+	// Any level, including registered ones
+	Log(level Level, v ...any)
+	Logf(level Level, format string, v ...any)
+	LogS(level Level, fields ...Data)
 
 	// Panic
 	Panic(v ...any)
 	Panicf(format string, v ...any)
 	PanicS(fields ...Data)
+	PanicCtx(ctx context.Context, msg string, fields ...Data)
+
+	// DPanic logs at ErrorLevel and panics in development mode
+	DPanic(v ...any)
+	DPanicf(format string, v ...any)
+	DPanicS(fields ...Data)
+	DPanicCtx(ctx context.Context, msg string, fields ...Data)
 
 	// Fatal
 	Fatal(v ...any)
 	Fatalf(format string, v ...any)
 	FatalS(fields ...Data)
+	FatalCtx(ctx context.Context, msg string, fields ...Data)
 
 	// Error
 	Error(v ...any)
 	Errorf(format string, v ...any)
 	ErrorS(fields ...Data)
+	ErrorCtx(ctx context.Context, msg string, fields ...Data)
 
 	// Warn
 	Warn(v ...any)
 	Warnf(format string, v ...any)
 	WarnS(fields ...Data)
+	WarnCtx(ctx context.Context, msg string, fields ...Data)
 
 	// Info
 	Info(v ...any)
 	Infof(format string, v ...any)
 	InfoS(fields ...Data)
+	InfoCtx(ctx context.Context, msg string, fields ...Data)
 
 	// Debug
 	Debug(v ...any)
 	Debugf(format string, v ...any)
 	DebugS(fields ...Data)
+	DebugCtx(ctx context.Context, msg string, fields ...Data)
 }
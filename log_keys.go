@@ -0,0 +1,92 @@
+package log
+
+import "github.com/nszilard/log/internal"
+
+// OmitKey used as a header key leaves the element out of entries.
+const OmitKey = "-"
+
+// HeaderKeys names the header elements of JSON and logfmt entries. An empty
+// key selects the default of the encoder and OmitKey leaves the element out.
+// Text and console entries have no keys and are not affected.
+type HeaderKeys struct {
+	Time       string // "timestamp" in JSON, "ts" in logfmt
+	Level      string // "level"
+	Caller     string // "caller"
+	Message    string // "msg"
+	Name       string // "logger"
+	Stacktrace string // "stacktrace", written after the fields (see OptionStacktrace)
+
+	// LevelName returns the value written under the level key, for backends
+	// with their own severity names. Nil writes the name of the level.
+	LevelName func(Level) string
+}
+
+// Presets of header keys for common log backends.
+var (
+	// ECSKeys follows the Elastic Common Schema.
+	ECSKeys = HeaderKeys{Time: "@timestamp", Level: "log.level", Message: "message", Name: "log.logger", Stacktrace: "error.stack_trace"}
+	// GCPKeys follows the structured logging format of Google Cloud Logging.
+	// Levels are written as the Cloud Logging severity matching their syslog
+	// severity, such as WARNING for WarnLevel and CRITICAL for FatalLevel.
+	GCPKeys = HeaderKeys{Time: "time", Level: "severity", Message: "message", Stacktrace: "stack_trace", LevelName: gcpSeverity}
+	// ShortKeys uses short keys, as in ts=... lvl=info msg=...
+	ShortKeys = HeaderKeys{Time: "ts", Level: "lvl", Message: "msg", Stacktrace: "stack"}
+)
+
+// OptionHeaderKeys sets the keys of the header elements of JSON and logfmt entries.
+func OptionHeaderKeys(keys HeaderKeys) Option {
+	return func(l *logger) {
+		if l.encoderConfig != nil {
+			l.encoderConfig.Keys = keys
+			l.buildCores()
+		}
+	}
+}
+
+// withDefaults returns the keys with empty ones replaced by defaults and
+// omitted ones emptied.
+func (keys HeaderKeys) withDefaults(defaults HeaderKeys) internal.HeaderKeys {
+	resolve := func(key, def string) string {
+		switch key {
+		case "":
+			return def
+		case OmitKey:
+			return ""
+		}
+		return key
+	}
+	return internal.HeaderKeys{
+		Time:       resolve(keys.Time, defaults.Time),
+		Level:      resolve(keys.Level, defaults.Level),
+		Name:       resolve(keys.Name, defaults.Name),
+		Caller:     resolve(keys.Caller, defaults.Caller),
+		Message:    resolve(keys.Message, defaults.Message),
+		Stacktrace: resolve(keys.Stacktrace, defaults.Stacktrace),
+	}
+}
+
+// levelNamer returns the function naming levels under the level key.
+func (keys HeaderKeys) levelNamer() func(Level) string {
+	if keys.LevelName != nil {
+		return keys.LevelName
+	}
+	return Level.String
+}
+
+// gcpSeverities are the Cloud Logging severities indexed by syslog severity.
+var gcpSeverities = [...]string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "NOTICE", "INFO", "DEBUG"}
+
+// gcpSeverity returns the Cloud Logging severity of level, or DEFAULT for
+// levels that are not defined.
+func gcpSeverity(level Level) string {
+	if s := level.SyslogSeverity(); s >= 0 && s < len(gcpSeverities) {
+		return gcpSeverities[s]
+	}
+	return "DEFAULT"
+}
+
+// Default header keys of the built-in encoders.
+var (
+	jsonDefaultKeys   = HeaderKeys{Time: "timestamp", Level: "level", Caller: "caller", Message: "msg", Name: "logger", Stacktrace: "stacktrace"}
+	logfmtDefaultKeys = HeaderKeys{Time: "ts", Level: "level", Caller: "caller", Message: "msg", Name: "logger", Stacktrace: "stacktrace"}
+)
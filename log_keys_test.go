@@ -0,0 +1,193 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestHeaderKeys(t *testing.T) {
+	entry := &Entry{
+		Level:      WarnLevel,
+		Time:       fixedClock(),
+		LoggerName: "db",
+		File:       "pool.go",
+		Line:       42,
+		Message:    "slow query",
+		Fields:     []Data{WithInt("rows", 3)},
+	}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{
+			"ECS",
+			NewJSONEncoder(EncoderConfig{Keys: ECSKeys}),
+			`{"@timestamp":"2025-09-25T13:20:18.524Z","log.level":"WARN","log.logger":"db","caller":"pool.go:42","message":"slow query","rows":3}` + "\n",
+		},
+		{
+			"GCP",
+			NewJSONEncoder(EncoderConfig{Keys: GCPKeys}),
+			`{"time":"2025-09-25T13:20:18.524Z","severity":"WARNING","logger":"db","caller":"pool.go:42","message":"slow query","rows":3}` + "\n",
+		},
+		{
+			"ShortLogfmt",
+			NewLogfmtEncoder(EncoderConfig{Keys: ShortKeys}),
+			`ts=2025-09-25T13:20:18.524Z lvl=warn logger=db caller=pool.go:42 msg="slow query" rows=3` + "\n",
+		},
+		{
+			"OmitTimeAndCaller",
+			NewJSONEncoder(EncoderConfig{Keys: HeaderKeys{Time: OmitKey, Caller: OmitKey, Name: "component"}}),
+			`{"level":"WARN","component":"db","msg":"slow query","rows":3}` + "\n",
+		},
+		{
+			"OmitTimeLogfmt",
+			NewLogfmtEncoder(EncoderConfig{Keys: HeaderKeys{Time: OmitKey, Level: "log level"}}),
+			`log_level=warn logger=db caller=pool.go:42 msg="slow query" rows=3` + "\n",
+		},
+		{
+			"EscapedKey",
+			NewJSONEncoder(EncoderConfig{Keys: HeaderKeys{Message: `"m"`}}),
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"WARN","logger":"db","caller":"pool.go:42","\"m\"":"slow query","rows":3}` + "\n",
+		},
+		{
+			"TextUnaffected",
+			NewTextEncoder(EncoderConfig{Keys: ShortKeys}),
+			"2025-09-25T13:20:18.524Z [WARN] [db] (pool.go:42) ▶ slow query rows=3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.encoder.EncodeEntry(nil, entry)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestGCPSeverity(t *testing.T) {
+	tests := []struct {
+		level    Level
+		severity string
+	}{
+		{PanicLevel, "EMERGENCY"},
+		{FatalLevel, "CRITICAL"},
+		{ErrorLevel, "ERROR"},
+		{WarnLevel, "WARNING"},
+		{InfoLevel, "INFO"},
+		{DebugLevel, "DEBUG"},
+		{Level(99), "DEFAULT"},
+	}
+
+	for _, tt := range tests {
+		if got := gcpSeverity(tt.level); got != tt.severity {
+			t.Errorf("gcpSeverity(%s) = %q, want %q", tt.level, got, tt.severity)
+		}
+	}
+}
+
+func TestHeaderKeysLevelName(t *testing.T) {
+	keys := HeaderKeys{LevelName: func(level Level) string { return "L" + strconv.Itoa(int(level)) }}
+	entry := &Entry{Level: InfoLevel, Time: fixedClock(), Message: "x"}
+
+	if got := string(NewJSONEncoder(EncoderConfig{Keys: keys}).EncodeEntry(nil, entry)); !strings.Contains(got, `"level":"L40"`) {
+		t.Errorf("Expected the level name in JSON, got %q", got)
+	}
+	if got := string(NewLogfmtEncoder(EncoderConfig{Keys: keys}).EncodeEntry(nil, entry)); !strings.Contains(got, " level=l40 ") {
+		t.Errorf("Expected the level name in logfmt, got %q", got)
+	}
+}
+
+func TestHeaderKeysStacktrace(t *testing.T) {
+	entry := &Entry{
+		Level:   ErrorLevel,
+		Time:    fixedClock(),
+		Message: "failed",
+		Fields:  []Data{WithInt("rows", 3)},
+		Stack:   "main.run\n\tmain.go:12\nmain.main\n\tmain.go:5",
+	}
+	omitted := HeaderKeys{Time: OmitKey, Level: OmitKey, Message: OmitKey}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{
+			"JSON",
+			NewJSONEncoder(EncoderConfig{TimeEncoding: TimeOmitted}),
+			`{"level":"ERROR","msg":"failed","rows":3,"stacktrace":"main.run\n\tmain.go:12\nmain.main\n\tmain.go:5"}` + "\n",
+		},
+		{
+			"ECS",
+			NewJSONEncoder(EncoderConfig{Keys: ECSKeys, TimeEncoding: TimeOmitted}),
+			`{"log.level":"ERROR","message":"failed","rows":3,"error.stack_trace":"main.run\n\tmain.go:12\nmain.main\n\tmain.go:5"}` + "\n",
+		},
+		{
+			"Omitted",
+			NewJSONEncoder(EncoderConfig{Keys: HeaderKeys{Stacktrace: OmitKey}, TimeEncoding: TimeOmitted}),
+			`{"level":"ERROR","msg":"failed","rows":3}` + "\n",
+		},
+		{
+			"Logfmt",
+			NewLogfmtEncoder(EncoderConfig{Keys: ShortKeys, TimeEncoding: TimeOmitted}),
+			`lvl=error msg=failed rows=3 stack="main.run\n\tmain.go:12\nmain.main\n\tmain.go:5"` + "\n",
+		},
+		{
+			"LogfmtOnlyStack",
+			NewLogfmtEncoder(EncoderConfig{Keys: omitted, TimeEncoding: TimeOmitted}),
+			`rows=3 stacktrace="main.run\n\tmain.go:12\nmain.main\n\tmain.go:5"` + "\n",
+		},
+		{
+			"Text",
+			NewTextEncoder(EncoderConfig{TimeEncoding: TimeOmitted}),
+			"[ERROR] ▶ failed rows=3\n\tmain.run\n\t\tmain.go:12\n\tmain.main\n\t\tmain.go:5\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.encoder.EncodeEntry(nil, entry)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestHeaderKeysAllOmitted(t *testing.T) {
+	keys := HeaderKeys{Time: OmitKey, Level: OmitKey, Caller: OmitKey, Message: OmitKey, Name: OmitKey}
+	entry := &Entry{Level: InfoLevel, Time: fixedClock(), LoggerName: "db", File: "pool.go", Line: 1, Message: "dropped"}
+
+	jsonEncoder := NewJSONEncoder(EncoderConfig{Keys: keys})
+	if got := string(jsonEncoder.EncodeEntry(nil, entry)); got != "{}\n" {
+		t.Errorf("Expected an empty object, got %q", got)
+	}
+	entry.Context = jsonEncoder.EncodeFields(nil, []Data{WithString("service", "api")})
+	entry.Fields = []Data{WithInt("rows", 3)}
+	got := jsonEncoder.EncodeEntry(nil, entry)
+	if string(got) != `{"service":"api","rows":3}`+"\n" || !json.Valid(got) {
+		t.Errorf("Expected the fields only, got %q", got)
+	}
+
+	logfmtEncoder := NewLogfmtEncoder(EncoderConfig{Keys: keys})
+	entry.Context = logfmtEncoder.EncodeFields(nil, []Data{WithString("service", "api")})
+	if got := string(logfmtEncoder.EncodeEntry(nil, entry)); got != "service=api rows=3\n" {
+		t.Errorf("Expected the fields only, got %q", got)
+	}
+}
+
+func TestOptionHeaderKeys(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false),
+		OptionHeaderKeys(ECSKeys), OptionTimeEncoding(TimeEpochMillis), OptionFormat(FormatJSON))
+
+	logger.Named("api").Info("started")
+	if want := `{"@timestamp":1758806418524,"log.level":"INFO","log.logger":"api","message":"started"}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
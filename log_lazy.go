@@ -0,0 +1,78 @@
+package log
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/nszilard/log/internal"
+)
+
+// firstLazy returns the index of the first lazy field, or -1 if there is none.
+func firstLazy(fields []Data) int {
+	for i := range fields {
+		if fields[i].Type == LazyType {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveLazy returns the lazily bound fields of the logger followed by
+// fields, with lazy fields replaced by their values. It returns fields as
+// they are when there is nothing to resolve.
+func (l *logger) resolveLazy(fields []Data) []Data {
+	if len(l.lazyContext) == 0 && firstLazy(fields) < 0 {
+		return fields
+	}
+	resolved := make([]Data, 0, len(l.lazyContext)+len(fields))
+	for _, group := range [2][]Data{l.lazyContext, fields} {
+		for _, field := range group {
+			if field.Type == LazyType {
+				field = resolveField(field)
+			}
+			resolved = append(resolved, field)
+		}
+	}
+	return resolved
+}
+
+// resolveField calls the function of a lazy field and returns the field
+// holding its result. A panicking function yields a placeholder string.
+func resolveField(field Data) (resolved Data) {
+	defer func() {
+		if r := recover(); r != nil {
+			resolved = WithString(field.Key, fmt.Sprintf("<panic: %v>", r))
+		}
+	}()
+	switch fn := field.Interface.(type) {
+	case func() string:
+		if fn != nil {
+			return WithString(field.Key, fn())
+		}
+	case func() any:
+		if fn != nil {
+			return WithAny(field.Key, fn())
+		}
+	}
+	return Data{Key: field.Key, Type: NilType}
+}
+
+// resolveLazyField returns the resolved form of field if it is lazy, and
+// field itself otherwise. Entries are resolved before hooks run; this covers
+// fields added by hooks and entries passed to the encoders directly.
+func resolveLazyField(field *internal.Data) *internal.Data {
+	if field.Type != internal.LazyType {
+		return field
+	}
+	resolved := resolveField(*fromInternalField(field))
+	return toInternalField(&resolved)
+}
+
+// lazyValuer defers a lazy field passed to a slog handler until the handler
+// resolves it.
+type lazyValuer Data
+
+// LogValue resolves the field.
+func (v lazyValuer) LogValue() slog.Value {
+	return toSlogAttr(resolveField(Data(v))).Value
+}
@@ -0,0 +1,113 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLazyFields(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	dump := func() any {
+		calls++
+		return map[string]int{"queued": 3}
+	}
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false))
+
+	logger.DebugS(WithLazy("state", dump))
+	if calls != 0 || buf.Len() != 0 {
+		t.Fatalf("Lazy fields should not be computed for filtered entries, got %d calls", calls)
+	}
+
+	logger.InfoS(WithLazy("state", dump), WithLazyString("mode", func() string { return "drain" }))
+	want := `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","state":{"queued":3},"mode":"drain"}` + "\n"
+	if calls != 1 || buf.String() != want {
+		t.Errorf("Expected %q after one call, got %q after %d calls", want, buf.String(), calls)
+	}
+}
+
+func TestLazyFieldsWithChildLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	var mu sync.Mutex
+	calls := 0
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false))
+	child := logger.With(
+		WithString("service", "queue"),
+		WithLazy("depth", func() any {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return calls
+		}),
+		WithString("region", "eu"),
+	).With(WithString("worker", "w1"))
+
+	child.Debug("filtered")
+	if calls != 0 {
+		t.Fatalf("Bound lazy fields should not be computed for filtered entries, got %d calls", calls)
+	}
+
+	child.Info("drained")
+	child.InfoS(WithInt("batch", 2))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 ||
+		lines[0] != "2025-09-25T13:20:18.524Z [INFO] ▶ drained service=queue depth=1 region=eu worker=w1" ||
+		lines[1] != `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","service":"queue","depth":2,"region":"eu","worker":"w1","batch":2}` {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			child.InfoS(WithInt("batch", 1))
+		}()
+	}
+	wg.Wait()
+	if calls != 10 {
+		t.Errorf("Expected one call per entry, got %d", calls)
+	}
+}
+
+func TestLazyFieldsInHooksAndPanics(t *testing.T) {
+	var buf bytes.Buffer
+	var seen []Data
+	logger := New(InfoLevel, &buf, OptionIncludeFileInfo(false), OptionHooks(NewHook(func(e *EntryView) error {
+		seen = append(seen, e.Fields()...)
+		e.AddFields(WithLazyString("added", func() string { return "by hook" }))
+		return nil
+	}))).With(WithLazyString("bound", func() string { return "ctx" }))
+
+	logger.InfoS(WithLazy("nil", nil), WithLazy("panics", func() any { panic("boom") }))
+	if len(seen) != 3 || seen[0].String != "ctx" || seen[1].Type != NilType || seen[2].String != "<panic: boom>" {
+		t.Errorf("Hooks should see resolved fields, got %+v", seen)
+	}
+	if !strings.Contains(buf.String(), `"bound":"ctx","nil":null,"panics":"<panic: boom>","added":"by hook"}`) {
+		t.Errorf("Unexpected output: %s", buf.String())
+	}
+}
+
+func TestLazyFieldsSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	sink := slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger := New(InfoLevel, nil, OptionSlogSink(sink), OptionIncludeFileInfo(false)).
+		With(WithString("service", "queue"), WithLazy("depth", func() any { calls++; return 4 }))
+
+	logger.Debug("filtered")
+	logger.Info("drained")
+	if want := "level=INFO msg=drained service=queue depth=4\n"; calls != 1 || buf.String() != want {
+		t.Errorf("Expected %q after one call, got %q after %d calls", want, buf.String(), calls)
+	}
+}
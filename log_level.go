@@ -1,10 +1,22 @@
 package log
 
-// Level represents the severity level of a log entry.
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Level represents the severity level of a log entry. Lower values are more
+// severe; the built-in levels are spaced apart so that levels registered with
+// RegisterLevel can be ordered between them. Store levels by name rather than
+// by value: the values of the built-in levels changed from 0-5 to 0-50 when
+// the registry was added.
 type Level uint8
 
 const (
-	PanicLevel Level = iota
+	PanicLevel Level = 10 * iota
 	FatalLevel
 	ErrorLevel
 	WarnLevel
@@ -12,19 +24,200 @@ const (
 	DebugLevel
 )
 
-var levelNames = []string{
-	"PANIC",
-	"FATAL",
-	"ERROR",
-	"WARN",
-	"INFO",
-	"DEBUG",
+// levelAliases holds additional names accepted by ParseLevel.
+var levelAliases = map[string]Level{
+	"WARNING": WarnLevel,
+	"ERR":     ErrorLevel,
 }
 
 // String returns the string representation of the level.
 func (l Level) String() string {
-	if int(l) >= len(levelNames) {
-		return ""
+	return levels.Load()[l].name
+}
+
+// ParseLevel returns the level with the given name. Names are matched without
+// regard to case or surrounding whitespace, and "warning" and "err" are
+// accepted as aliases of WARN and ERROR. Registered levels are recognized too.
+func ParseLevel(name string) (Level, error) {
+	trimmed := strings.TrimSpace(name)
+	table := levels.Load()
+	for i := range table {
+		if table[i].name != "" && strings.EqualFold(trimmed, table[i].name) {
+			return Level(i), nil
+		}
+	}
+	if level, ok := levelAliases[strings.ToUpper(trimmed)]; ok {
+		return level, nil
+	}
+	return 0, fmt.Errorf("unknown level %q", name)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	name := l.String()
+	if name == "" {
+		return nil, fmt.Errorf("invalid level %d", l)
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the level as its name.
+func (l Level) MarshalJSON() ([]byte, error) {
+	text, err := l.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return strconv.AppendQuote(make([]byte, 0, len(text)+2), string(text)), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a level name as a JSON string.
+func (l *Level) UnmarshalJSON(data []byte) error {
+	name, err := strconv.Unquote(string(data))
+	if err != nil {
+		return fmt.Errorf("level must be a JSON string, got %s", data)
+	}
+	return l.UnmarshalText([]byte(name))
+}
+
+// Set implements flag.Value using ParseLevel.
+func (l *Level) Set(name string) error {
+	return l.UnmarshalText([]byte(name))
+}
+
+// AtomicLevel is a Level that can be read and changed concurrently.
+// Sharing one AtomicLevel between loggers lets their level be changed
+// together at runtime. The zero value holds PanicLevel.
+type AtomicLevel struct {
+	v atomic.Uint32
+}
+
+// NewAtomicLevel creates an AtomicLevel set to level.
+func NewAtomicLevel(level Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.SetLevel(level)
+	return a
+}
+
+// Level returns the current level.
+func (a *AtomicLevel) Level() Level {
+	return Level(a.v.Load())
+}
+
+// SetLevel changes the level.
+func (a *AtomicLevel) SetLevel(level Level) {
+	a.v.Store(uint32(level))
+}
+
+// Enabled reports whether entries at level pass the current level.
+func (a *AtomicLevel) Enabled(level Level) bool {
+	return level <= a.Level()
+}
+
+// String returns the string representation of the current level.
+func (a *AtomicLevel) String() string {
+	return a.Level().String()
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (a *AtomicLevel) MarshalText() ([]byte, error) {
+	return a.Level().MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseLevel.
+func (a *AtomicLevel) UnmarshalText(text []byte) error {
+	var level Level
+	if err := level.UnmarshalText(text); err != nil {
+		return err
+	}
+	a.SetLevel(level)
+	return nil
+}
+
+// levelTreeMu guards the children of every levelNode.
+var levelTreeMu sync.Mutex
+
+// levelNode holds the level of one name in a tree of named loggers.
+// A node without a level of its own inherits the level of its closest
+// ancestor that has one; the root always has a level.
+type levelNode struct {
+	parent   *levelNode
+	children map[string]*levelNode
+	level    atomic.Pointer[AtomicLevel]
+}
+
+// newLevelNode creates the root of a level tree.
+func newLevelNode(level *AtomicLevel) *levelNode {
+	n := &levelNode{}
+	n.level.Store(level)
+	return n
+}
+
+// effective returns the level of the node or of its closest ancestor with one.
+func (n *levelNode) effective() Level {
+	level := n.level.Load()
+	for level == nil {
+		n = n.parent
+		level = n.level.Load()
+	}
+	return level.Level()
+}
+
+// set overrides the level of the node.
+func (n *levelNode) set(level Level) {
+	if current := n.level.Load(); current != nil {
+		current.SetLevel(level)
+		return
+	}
+	if !n.level.CompareAndSwap(nil, NewAtomicLevel(level)) {
+		n.level.Load().SetLevel(level)
+	}
+}
+
+// child returns the node for the given name segment, creating it if needed.
+func (n *levelNode) child(segment string) *levelNode {
+	levelTreeMu.Lock()
+	defer levelTreeMu.Unlock()
+
+	c, ok := n.children[segment]
+	if !ok {
+		if n.children == nil {
+			n.children = make(map[string]*levelNode)
+		}
+		c = &levelNode{parent: n}
+		n.children[segment] = c
+	}
+	return c
+}
+
+// find returns the node for the given name segment, or nil if there is none.
+func (n *levelNode) find(segment string) *levelNode {
+	levelTreeMu.Lock()
+	defer levelTreeMu.Unlock()
+	return n.children[segment]
+}
+
+// lookup returns the node for the given dotted name without creating any.
+// If the name has no node, it returns the closest ancestor that has one and false.
+func (n *levelNode) lookup(name string) (*levelNode, bool) {
+	for segment := range strings.SplitSeq(name, ".") {
+		if segment == "" {
+			continue
+		}
+		child := n.find(segment)
+		if child == nil {
+			return n, false
+		}
+		n = child
 	}
-	return levelNames[l]
+	return n, true
 }
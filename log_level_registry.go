@@ -0,0 +1,133 @@
+package log
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// levelInfo describes a built-in or registered level.
+type levelInfo struct {
+	name   string
+	syslog int
+	otel   int
+}
+
+// levelTable holds the description of every possible Level; unused levels
+// have an empty name. Tables are never modified once published.
+type levelTable [256]levelInfo
+
+var (
+	// levels holds the current level table, replaced as a whole on registration.
+	levels atomic.Pointer[levelTable]
+	// registerMu serializes registrations.
+	registerMu sync.Mutex
+)
+
+func init() {
+	table := &levelTable{}
+	table[PanicLevel] = levelInfo{name: "PANIC", syslog: 0, otel: 24}
+	table[FatalLevel] = levelInfo{name: "FATAL", syslog: 2, otel: 21}
+	table[ErrorLevel] = levelInfo{name: "ERROR", syslog: 3, otel: 17}
+	table[WarnLevel] = levelInfo{name: "WARN", syslog: 4, otel: 13}
+	table[InfoLevel] = levelInfo{name: "INFO", syslog: 6, otel: 9}
+	table[DebugLevel] = levelInfo{name: "DEBUG", syslog: 7, otel: 5}
+	levels.Store(table)
+}
+
+// LevelOption configures a level registered with RegisterLevel.
+type LevelOption func(*levelInfo)
+
+// LevelSyslog sets the RFC 5424 syslog severity of a level, from
+// 0 (Emergency) to 7 (Debug).
+func LevelSyslog(severity int) LevelOption {
+	return func(info *levelInfo) {
+		info.syslog = severity
+	}
+}
+
+// LevelOTel sets the OpenTelemetry severity number of a level, from
+// 1 (TRACE) to 24 (FATAL4).
+func LevelOTel(severity int) LevelOption {
+	return func(info *levelInfo) {
+		info.otel = severity
+	}
+}
+
+// RegisterLevel defines an additional level. Its value orders it among the
+// other levels: entries at the new level are written by loggers whose level
+// is the same or a higher value. For example, a TRACE level more verbose than
+// DEBUG can be registered as DebugLevel+10 and a NOTICE level between WARN and
+// INFO as WarnLevel+5.
+//
+// Severities that are not set with LevelSyslog or LevelOTel are taken from the
+// closest more severe level. The name must be unique, ignoring case, and the
+// value must not be in use. Levels are usually registered during
+// initialization, but registering is safe at any time.
+//
+// Names are written unquoted, so they may only contain ASCII letters, digits,
+// underscores and hyphens.
+func RegisterLevel(level Level, name string, opts ...LevelOption) error {
+	if !validLevelName(name) {
+		return fmt.Errorf("invalid level name %q", name)
+	}
+
+	registerMu.Lock()
+	defer registerMu.Unlock()
+
+	current := levels.Load()
+	if current[level].name != "" {
+		return fmt.Errorf("level %d is already registered as %s", level, current[level].name)
+	}
+	if _, err := ParseLevel(name); err == nil {
+		return fmt.Errorf("level name %q is already registered", name)
+	}
+
+	info := levelInfo{name: name}
+	for i := int(level) - 1; i >= 0; i-- {
+		if current[i].name != "" {
+			info.syslog, info.otel = current[i].syslog, current[i].otel
+			break
+		}
+	}
+	for _, opt := range opts {
+		opt(&info)
+	}
+
+	table := *current
+	table[level] = info
+	levels.Store(&table)
+	return nil
+}
+
+// validLevelName reports whether name can be written as a level without
+// quoting in every format.
+func validLevelName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range []byte(name) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// SyslogSeverity returns the RFC 5424 syslog severity of the level,
+// or -1 for levels that are not defined.
+func (l Level) SyslogSeverity() int {
+	info := levels.Load()[l]
+	if info.name == "" {
+		return -1
+	}
+	return info.syslog
+}
+
+// OTelSeverity returns the OpenTelemetry severity number of the level,
+// or 0 (UNSPECIFIED) for levels that are not defined.
+func (l Level) OTelSeverity() int {
+	return levels.Load()[l].otel
+}
@@ -0,0 +1,170 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	testTraceLevel    = DebugLevel + 10
+	testNoticeLevel   = WarnLevel + 5
+	testCriticalLevel = ErrorLevel - 5
+)
+
+var registerTestLevelsOnce sync.Once
+
+func registerTestLevels(t *testing.T) {
+	t.Helper()
+	registerTestLevelsOnce.Do(func() {
+		for _, err := range []error{
+			RegisterLevel(testTraceLevel, "TRACE", LevelOTel(1)),
+			RegisterLevel(testNoticeLevel, "NOTICE", LevelSyslog(5), LevelOTel(10)),
+			RegisterLevel(testCriticalLevel, "CRITICAL"),
+		} {
+			if err != nil {
+				t.Fatalf("RegisterLevel failed: %v", err)
+			}
+		}
+	})
+}
+
+func TestRegisterLevel(t *testing.T) {
+	registerTestLevels(t)
+
+	tests := []struct {
+		level  Level
+		name   string
+		syslog int
+		otel   int
+	}{
+		{PanicLevel, "PANIC", 0, 24},
+		{testCriticalLevel, "CRITICAL", 2, 21},
+		{ErrorLevel, "ERROR", 3, 17},
+		{WarnLevel, "WARN", 4, 13},
+		{testNoticeLevel, "NOTICE", 5, 10},
+		{InfoLevel, "INFO", 6, 9},
+		{DebugLevel, "DEBUG", 7, 5},
+		{testTraceLevel, "TRACE", 7, 1},
+		{Level(99), "", -1, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.level.String(); got != tt.name {
+			t.Errorf("Level(%d).String() = %q, want %q", tt.level, got, tt.name)
+		}
+		if got := tt.level.SyslogSeverity(); got != tt.syslog {
+			t.Errorf("%s.SyslogSeverity() = %d, want %d", tt.name, got, tt.syslog)
+		}
+		if got := tt.level.OTelSeverity(); got != tt.otel {
+			t.Errorf("%s.OTelSeverity() = %d, want %d", tt.name, got, tt.otel)
+		}
+		if tt.name == "" {
+			continue
+		}
+		if level, err := ParseLevel(strings.ToLower(tt.name)); err != nil || level != tt.level {
+			t.Errorf("ParseLevel(%q) = %d, %v", tt.name, level, err)
+		}
+	}
+}
+
+func TestRegisterLevelErrors(t *testing.T) {
+	registerTestLevels(t)
+
+	tests := []struct {
+		name  string
+		level Level
+	}{
+		{"", 99},
+		{"TWO WORDS", 99},
+		{`QUOTE"`, 99},
+		{"SINGLE'QUOTE", 99},
+		{"my=lvl", 99},
+		{"[BRACKET]", 99},
+		{"ÉVÉNEMENT", 99},
+		{"trace", 99},
+		{"warning", 99},
+		{"VERBOSE", InfoLevel},
+	}
+
+	for _, tt := range tests {
+		if err := RegisterLevel(tt.level, tt.name); err == nil {
+			t.Errorf("RegisterLevel(%d, %q) should fail", tt.level, tt.name)
+		}
+	}
+}
+
+func TestCustomLevelLogging(t *testing.T) {
+	registerTestLevels(t)
+
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionIncludeFileInfo(false))
+
+	tests := []struct {
+		name     string
+		level    Level
+		logFn    func()
+		expected string
+	}{
+		{"Log filtered", InfoLevel, func() { logger.Log(testTraceLevel, "trace msg") }, ""},
+		{"Log", InfoLevel, func() { logger.Log(testNoticeLevel, "notice", "msg") }, "[NOTICE] ▶ notice msg\n"},
+		{"Logf", InfoLevel, func() { logger.Logf(testCriticalLevel, "disk %d full", 99) }, "[CRITICAL] ▶ disk 99 full\n"},
+		{"LogS", InfoLevel, func() { logger.LogS(testNoticeLevel, WithInt("n", 1)) }, `"level":"NOTICE","n":1}` + "\n"},
+		{"LogS filtered", WarnLevel, func() { logger.LogS(testNoticeLevel, WithInt("n", 1)) }, ""},
+		{"Trace enabled", testTraceLevel, func() { logger.Log(testTraceLevel, "trace msg") }, "[TRACE] ▶ trace msg\n"},
+		{"Debug at trace", testTraceLevel, func() { logger.Debug("debug msg") }, "[DEBUG] ▶ debug msg\n"},
+		{"Log never panics", InfoLevel, func() { logger.Log(PanicLevel, "no panic") }, "[PANIC] ▶ no panic\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			logger.SetLevel(tt.level)
+			tt.logFn()
+			if tt.expected == "" && buf.Len() > 0 {
+				t.Errorf("Expected no output, got %q", buf.String())
+			}
+			if !strings.HasSuffix(buf.String(), tt.expected) {
+				t.Errorf("Expected suffix %q in output: %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestCustomLevelDefaultLogger(t *testing.T) {
+	registerTestLevels(t)
+	buf, cleanup := setupTestLogger(t, testTraceLevel)
+	defer cleanup()
+	defer SetLevel(InfoLevel)
+
+	Log(testTraceLevel, "trace")
+	Logf(testTraceLevel, "trace %d", 2)
+	LogS(testTraceLevel, WithInt("n", 3))
+	if lines := strings.Count(buf.String(), "TRACE"); lines != 3 {
+		t.Errorf("Expected 3 TRACE entries, got %d: %s", lines, buf.String())
+	}
+}
+
+func TestToSlogLevel(t *testing.T) {
+	tests := []struct {
+		level    Level
+		expected slog.Level
+	}{
+		{PanicLevel, slog.LevelError + 8},
+		{FatalLevel, slog.LevelError + 4},
+		{ErrorLevel, slog.LevelError},
+		{WarnLevel, slog.LevelWarn},
+		{WarnLevel + 5, slog.LevelInfo + 2},
+		{InfoLevel, slog.LevelInfo},
+		{DebugLevel, slog.LevelDebug},
+		{DebugLevel + 10, slog.LevelDebug - 4},
+	}
+
+	for _, tt := range tests {
+		if got := toSlogLevel(tt.level); got != tt.expected {
+			t.Errorf("toSlogLevel(%d) = %v, want %v", tt.level, got, tt.expected)
+		}
+	}
+}
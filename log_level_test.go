@@ -1,6 +1,13 @@
 package log
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io"
+	"sync"
+	"testing"
+)
 
 func TestLevelString(t *testing.T) {
 	tests := map[Level]string{
@@ -20,3 +27,170 @@ func TestLevelString(t *testing.T) {
 		}
 	}
 }
+
+func TestAtomicLevel(t *testing.T) {
+	level := NewAtomicLevel(InfoLevel)
+	if level.Level() != InfoLevel || level.String() != "INFO" {
+		t.Errorf("Expected INFO, got %s", level)
+	}
+	if level.Enabled(DebugLevel) || !level.Enabled(WarnLevel) {
+		t.Error("Unexpected Enabled result at INFO")
+	}
+
+	level.SetLevel(DebugLevel)
+	if !level.Enabled(DebugLevel) {
+		t.Error("Debug should be enabled after SetLevel(DebugLevel)")
+	}
+
+	var zero AtomicLevel
+	if zero.Level() != PanicLevel {
+		t.Errorf("Zero value should hold PANIC, got %s", zero.String())
+	}
+}
+
+func TestSharedAtomicLevel(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	level := NewAtomicLevel(WarnLevel)
+	logger1 := New(InfoLevel, &buf1, OptionAtomicLevel(level))
+	logger2 := New(InfoLevel, &buf2, OptionAtomicLevel(level))
+
+	logger1.Info("filtered")
+	logger2.Info("filtered")
+	if buf1.Len() > 0 || buf2.Len() > 0 {
+		t.Error("Both loggers should use the shared WARN level")
+	}
+
+	level.SetLevel(InfoLevel)
+	logger1.Info("written")
+	logger2.Info("written")
+	if buf1.Len() == 0 || buf2.Len() == 0 {
+		t.Error("Both loggers should follow the shared level change")
+	}
+
+	logger1.SetLevel(ErrorLevel)
+	if level.Level() != ErrorLevel {
+		t.Errorf("SetLevel on a root logger should change its AtomicLevel, got %s", level)
+	}
+}
+
+func TestConcurrentConfiguration(t *testing.T) {
+	logger := New(InfoLevel, io.Discard)
+	child := logger.Named("child").With(WithString("k", "v"))
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			logger.SetLevel(Level(i%6) * 10)
+			child.SetLevel(Level(i%6) * 10)
+			logger.SetIncludeFileInfo(i%2 == 0)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			logger.Info("test")
+			child.InfoS(WithInt("n", 1))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			_ = logger.Named("other").With(WithInt("n", 1))
+		}
+	}()
+	wg.Wait()
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected Level
+		wantErr  bool
+	}{
+		{"panic", PanicLevel, false},
+		{"FATAL", FatalLevel, false},
+		{"Error", ErrorLevel, false},
+		{"err", ErrorLevel, false},
+		{"warn", WarnLevel, false},
+		{"WARNING", WarnLevel, false},
+		{" info ", InfoLevel, false},
+		{"debug", DebugLevel, false},
+		{"", 0, true},
+		{"verbose", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseLevel(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseLevel(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			}
+			if level != tt.expected {
+				t.Errorf("ParseLevel(%q) = %s, want %s", tt.name, level, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLevelMarshalling(t *testing.T) {
+	type config struct {
+		Level Level `json:"level"`
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(config{Level: WarnLevel})
+		if err != nil || string(data) != `{"level":"WARN"}` {
+			t.Errorf("Unexpected JSON %s, error %v", data, err)
+		}
+
+		var cfg config
+		if err := json.Unmarshal([]byte(`{"level":"warning"}`), &cfg); err != nil || cfg.Level != WarnLevel {
+			t.Errorf("Unexpected level %s, error %v", cfg.Level, err)
+		}
+		if err := json.Unmarshal([]byte(`{"level":3}`), &cfg); err == nil {
+			t.Error("Expected error for a numeric level")
+		}
+		if err := json.Unmarshal([]byte(`{"level":"loud"}`), &cfg); err == nil {
+			t.Error("Expected error for an unknown level")
+		}
+		if _, err := json.Marshal(config{Level: Level(99)}); err == nil {
+			t.Error("Expected error when marshalling an invalid level")
+		}
+	})
+
+	t.Run("Text", func(t *testing.T) {
+		text, err := DebugLevel.MarshalText()
+		if err != nil || string(text) != "DEBUG" {
+			t.Errorf("Unexpected text %s, error %v", text, err)
+		}
+
+		var level Level
+		if err := level.UnmarshalText([]byte("Err")); err != nil || level != ErrorLevel {
+			t.Errorf("Unexpected level %s, error %v", level, err)
+		}
+
+		atomicLevel := NewAtomicLevel(InfoLevel)
+		if err := atomicLevel.UnmarshalText([]byte("debug")); err != nil || atomicLevel.Level() != DebugLevel {
+			t.Errorf("Unexpected atomic level %s, error %v", atomicLevel, err)
+		}
+		if text, err := atomicLevel.MarshalText(); err != nil || string(text) != "DEBUG" {
+			t.Errorf("Unexpected atomic text %s, error %v", text, err)
+		}
+	})
+
+	t.Run("Flag", func(t *testing.T) {
+		level := InfoLevel
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(&level, "level", "log level")
+
+		if err := fs.Parse([]string{"-level", "debug"}); err != nil || level != DebugLevel {
+			t.Errorf("Unexpected level %s, error %v", level, err)
+		}
+		if err := fs.Parse([]string{"-level", "loud"}); err == nil {
+			t.Error("Expected error for an unknown level")
+		}
+	})
+}
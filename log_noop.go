@@ -1,28 +1,48 @@
 package log
 
-import "io"
+import (
+	"context"
+	"io"
+)
 
 // NoopLogger implements Logger but discards all log messages and doesn't panic
 type NoopLogger struct{}
 
-func (NoopLogger) SetLevel(Level)                 {}
-func (NoopLogger) SetOutput(io.Writer)            {}
-func (NoopLogger) SetIncludeFileInfo(bool)        {}
-func (NoopLogger) Debug(...any)                   {}
-func (NoopLogger) Debugf(string, ...any)          {}
-func (NoopLogger) DebugS(...Data)                 {}
-func (NoopLogger) Info(...any)                    {}
-func (NoopLogger) Infof(string, ...any)           {}
-func (NoopLogger) InfoS(...Data)                  {}
-func (NoopLogger) Warn(...any)                    {}
-func (NoopLogger) Warnf(string, ...any)           {}
-func (NoopLogger) WarnS(...Data)                  {}
-func (NoopLogger) Error(...any)                   {}
-func (NoopLogger) Errorf(string, ...any)          {}
-func (NoopLogger) ErrorS(...Data)                 {}
-func (NoopLogger) Fatal(...any)                   {}
-func (NoopLogger) Fatalf(string, ...any)          {}
-func (NoopLogger) FatalS(...Data)                 {}
-func (NoopLogger) Panic(v ...any)                 {}
-func (NoopLogger) Panicf(format string, v ...any) {}
-func (NoopLogger) PanicS(fields ...Data)          {}
+func (NoopLogger) SetLevel(Level)                             {}
+func (NoopLogger) SetOutput(io.Writer)                        {}
+func (NoopLogger) SetIncludeFileInfo(bool)                    {}
+func (NoopLogger) Sync() error                                { return nil }
+func (NoopLogger) AddHook(Hook)                               {}
+func (n NoopLogger) With(...Data) Logger                      { return n }
+func (n NoopLogger) Named(string) Logger                      { return n }
+func (NoopLogger) Log(Level, ...any)                          {}
+func (NoopLogger) Logf(Level, string, ...any)                 {}
+func (NoopLogger) LogS(Level, ...Data)                        {}
+func (NoopLogger) Debug(...any)                               {}
+func (NoopLogger) Debugf(string, ...any)                      {}
+func (NoopLogger) DebugS(...Data)                             {}
+func (NoopLogger) DebugCtx(context.Context, string, ...Data)  {}
+func (NoopLogger) Info(...any)                                {}
+func (NoopLogger) Infof(string, ...any)                       {}
+func (NoopLogger) InfoS(...Data)                              {}
+func (NoopLogger) InfoCtx(context.Context, string, ...Data)   {}
+func (NoopLogger) Warn(...any)                                {}
+func (NoopLogger) Warnf(string, ...any)                       {}
+func (NoopLogger) WarnS(...Data)                              {}
+func (NoopLogger) WarnCtx(context.Context, string, ...Data)   {}
+func (NoopLogger) Error(...any)                               {}
+func (NoopLogger) Errorf(string, ...any)                      {}
+func (NoopLogger) ErrorS(...Data)                             {}
+func (NoopLogger) ErrorCtx(context.Context, string, ...Data)  {}
+func (NoopLogger) DPanic(...any)                              {}
+func (NoopLogger) DPanicf(string, ...any)                     {}
+func (NoopLogger) DPanicS(...Data)                            {}
+func (NoopLogger) DPanicCtx(context.Context, string, ...Data) {}
+func (NoopLogger) Fatal(...any)                               {}
+func (NoopLogger) Fatalf(string, ...any)                      {}
+func (NoopLogger) FatalS(...Data)                             {}
+func (NoopLogger) FatalCtx(context.Context, string, ...Data)  {}
+func (NoopLogger) Panic(v ...any)                             {}
+func (NoopLogger) Panicf(format string, v ...any)             {}
+func (NoopLogger) PanicS(fields ...Data)                      {}
+func (NoopLogger) PanicCtx(context.Context, string, ...Data)  {}
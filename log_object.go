@@ -0,0 +1,227 @@
+package log
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nszilard/log/internal"
+)
+
+// ObjectMarshaler is implemented by types that write themselves as an object
+// of typed fields, avoiding the reflection used for WithAny values.
+type ObjectMarshaler interface {
+	MarshalLogObject(enc ObjectEncoder) error
+}
+
+// ObjectMarshalerFunc adapts a function to the ObjectMarshaler interface.
+type ObjectMarshalerFunc func(enc ObjectEncoder) error
+
+// MarshalLogObject calls f.
+func (f ObjectMarshalerFunc) MarshalLogObject(enc ObjectEncoder) error {
+	return f(enc)
+}
+
+// ObjectEncoder receives the fields of an object. JSON output nests them
+// under the key of the object; text, logfmt and console output flatten them
+// into dotted keys, such as http.method=GET.
+type ObjectEncoder interface {
+	// AddField adds a field to the object.
+	AddField(field Data)
+	// AddFields adds several fields to the object.
+	AddFields(fields ...Data)
+}
+
+// group is an object made of a fixed list of fields.
+type group []Data
+
+// MarshalLogObject adds the fields of the group.
+func (g group) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddFields(g...)
+	return nil
+}
+
+// objectMarshaler returns the marshaler of an object field, or of an untyped
+// field whose value implements ObjectMarshaler.
+func objectMarshaler(field *internal.Data) (ObjectMarshaler, bool) {
+	if field.Type != internal.ObjectType && field.Type != internal.UnknownType {
+		return nil, false
+	}
+	m, ok := field.Interface.(ObjectMarshaler)
+	return m, ok
+}
+
+// jsonObjectEncoder writes the fields of an object as JSON members.
+type jsonObjectEncoder struct {
+	buf    []byte
+	empty  bool
+	fields *fieldEncoding
+}
+
+var jsonObjectEncoderPool = sync.Pool{New: func() any { return &jsonObjectEncoder{} }}
+
+// AddField appends field as a member of the object. The comma written before
+// the first member is replaced by the opening brace.
+func (enc *jsonObjectEncoder) AddField(field Data) {
+	start := len(enc.buf)
+	enc.buf = appendJSONField(enc.buf, toInternalField(&field), false, enc.fields)
+	if enc.empty {
+		enc.buf[start] = '{'
+		enc.empty = false
+	}
+}
+
+// AddFields appends fields as members of the object.
+func (enc *jsonObjectEncoder) AddFields(fields ...Data) {
+	for _, field := range fields {
+		enc.AddField(field)
+	}
+}
+
+// appendJSONField appends field as a JSON member preceded by a comma. With
+// nullErrors, nil errors are written as null instead of an empty string.
+// Objects and arrays whose marshaler fails are followed by a "<key>Error"
+// member.
+func appendJSONField(buf []byte, field *internal.Data, nullErrors bool, fe *fieldEncoding) []byte {
+	field = resolveLazyField(field)
+	buf = internal.AppendJSONKey(buf, field.Key)
+	var err error
+	if m, ok := objectMarshaler(field); ok {
+		buf, err = appendJSONObject(buf, m, fe)
+	} else if m, ok := arrayMarshaler(field); ok {
+		buf, err = appendJSONArray(buf, m, fe)
+	} else if t, ok := field.Interface.(time.Time); ok && field.Type == internal.TimeType {
+		return fe.time.appendJSON(buf, t)
+	} else if field.Type == internal.DurationType {
+		return appendJSONDuration(buf, time.Duration(field.Integer), fe.duration)
+	} else if nullErrors && field.Type == internal.ErrorType && field.Interface == nil {
+		return append(buf, "null"...)
+	} else {
+		return internal.AppendTypedJSONValue(buf, field)
+	}
+	if err != nil {
+		buf = internal.AppendJSONKey(buf, field.Key+"Error")
+		buf = internal.AppendQuoted(buf, err.Error())
+	}
+	return buf
+}
+
+// appendJSONObject appends the fields added by m as a JSON object.
+func appendJSONObject(buf []byte, m ObjectMarshaler, fe *fieldEncoding) ([]byte, error) {
+	enc := jsonObjectEncoderPool.Get().(*jsonObjectEncoder)
+	enc.buf, enc.empty, enc.fields = buf, true, fe
+	err := m.MarshalLogObject(enc)
+	buf = enc.buf
+	if enc.empty {
+		buf = append(buf, '{')
+	}
+	buf = append(buf, '}')
+	enc.buf, enc.fields = nil, nil
+	jsonObjectEncoderPool.Put(enc)
+	return buf, err
+}
+
+// flatStyle selects how flattened fields are written.
+type flatStyle uint8
+
+const (
+	textStyle flatStyle = iota
+	logfmtStyle
+)
+
+// flatObjectEncoder writes the fields of an object as key=value pairs whose
+// keys are prefixed with the keys of the enclosing objects.
+type flatObjectEncoder struct {
+	buf    []byte
+	prefix string
+	style  flatStyle
+	fields *fieldEncoding
+}
+
+var flatObjectEncoderPool = sync.Pool{New: func() any { return &flatObjectEncoder{} }}
+
+// AddField appends field with the prefix of the object.
+func (enc *flatObjectEncoder) AddField(field Data) {
+	enc.buf = appendFlatField(enc.buf, enc.prefix, toInternalField(&field), enc.style, enc.fields)
+}
+
+// AddFields appends fields with the prefix of the object.
+func (enc *flatObjectEncoder) AddFields(fields ...Data) {
+	for _, field := range fields {
+		enc.AddField(field)
+	}
+}
+
+// appendFlatFields appends fields as " key=value" pairs in the given style.
+func appendFlatFields(buf []byte, fields []Data, style flatStyle, fe *fieldEncoding) []byte {
+	fs := toInternal(fields)
+	for i := range fs {
+		buf = appendFlatField(buf, "", &fs[i], style, fe)
+	}
+	return buf
+}
+
+// appendFlatField appends field as a " prefixkey=value" pair, or objects as
+// one pair per nested field. Objects and arrays whose marshaler fails are
+// followed by a "<key>Error" pair.
+func appendFlatField(buf []byte, prefix string, field *internal.Data, style flatStyle, fe *fieldEncoding) []byte {
+	field = resolveLazyField(field)
+	if m, ok := objectMarshaler(field); ok {
+		enc := flatObjectEncoderPool.Get().(*flatObjectEncoder)
+		enc.buf, enc.prefix, enc.style, enc.fields = buf, prefix+field.Key+".", style, fe
+		err := m.MarshalLogObject(enc)
+		buf = enc.buf
+		enc.buf, enc.prefix, enc.fields = nil, "", nil
+		flatObjectEncoderPool.Put(enc)
+		if err != nil {
+			errField := internal.StringField(field.Key+"Error", err.Error())
+			buf = appendFlatField(buf, prefix, &errField, style, fe)
+		}
+		return buf
+	}
+	if m, ok := arrayMarshaler(field); ok {
+		return appendFlatArray(buf, prefix, field.Key, m, style, fe)
+	}
+	t, isTime := field.Interface.(time.Time)
+	isTime = isTime && field.Type == internal.TimeType
+	if style == logfmtStyle {
+		buf = internal.AppendLogfmtKey(buf, prefix, field.Key)
+		if isTime {
+			return fe.time.appendLogfmt(buf, t)
+		}
+		if field.Type == internal.DurationType {
+			return appendDuration(buf, time.Duration(field.Integer), fe.duration)
+		}
+		return internal.AppendTypedLogfmtValue(buf, field)
+	}
+	buf = internal.AppendTextKey(buf, prefix, field.Key)
+	if isTime {
+		return fe.time.appendTime(buf, t)
+	}
+	if field.Type == internal.DurationType {
+		return appendDuration(buf, time.Duration(field.Integer), fe.duration)
+	}
+	return internal.AppendTypedTextValue(buf, field)
+}
+
+// objectFields returns the fields added by m, for consumers that cannot
+// encode objects themselves.
+func objectFields(m ObjectMarshaler) ([]Data, error) {
+	var c fieldCollector
+	err := m.MarshalLogObject(&c)
+	return c.fields, err
+}
+
+// fieldCollector is an ObjectEncoder that records the fields added to it.
+type fieldCollector struct {
+	fields []Data
+}
+
+// AddField records field.
+func (c *fieldCollector) AddField(field Data) {
+	c.fields = append(c.fields, field)
+}
+
+// AddFields records fields.
+func (c *fieldCollector) AddFields(fields ...Data) {
+	c.fields = append(c.fields, fields...)
+}
@@ -0,0 +1,160 @@
+package log
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type request struct {
+	method string
+	status int
+}
+
+func (r request) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddField(WithString("method", r.method))
+	enc.AddField(WithInt("status", int64(r.status)))
+	return nil
+}
+
+func TestObjectFields(t *testing.T) {
+	fields := []Data{
+		WithObject("http", request{method: "GET", status: 200}),
+		WithGroup("db", WithString("table", "users"), WithGroup("pool", WithInt("size", 4))),
+	}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{
+			"JSON",
+			NewJSONEncoder(EncoderConfig{}),
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","http":{"method":"GET","status":200},"db":{"table":"users","pool":{"size":4}}}` + "\n",
+		},
+		{
+			"Text",
+			NewTextEncoder(EncoderConfig{}),
+			"2025-09-25T13:20:18.524Z [INFO] ▶ http.method=GET http.status=200 db.table=users db.pool.size=4\n",
+		},
+		{
+			"Logfmt",
+			NewLogfmtEncoder(EncoderConfig{}),
+			"ts=2025-09-25T13:20:18.524Z level=info http.method=GET http.status=200 db.table=users db.pool.size=4\n",
+		},
+		{
+			"Console",
+			NewConsoleEncoder(EncoderConfig{}, false),
+			"2025-09-25T13:20:18.524Z INFO  ▶ http.method=GET http.status=200 db.table=users db.pool.size=4\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Entry{Level: InfoLevel, Time: fixedClock(), Fields: fields}
+			if got := string(tt.encoder.EncodeEntry(nil, e)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestObjectEdgeCases(t *testing.T) {
+	failing := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		enc.AddFields(WithString("id", "42"))
+		return errors.New("incomplete")
+	})
+
+	tests := []struct {
+		name   string
+		field  Data
+		json   string
+		logfmt string
+	}{
+		{"Empty group", WithGroup("empty"), `"empty":{}`, ""},
+		{"Nil object", WithObject("obj", nil), `"obj":null`, ` obj=null`},
+		{"Untyped marshaler", WithAny("http", request{method: "POST", status: 201}), `"http":{"method":"POST","status":201}`, ` http.method=POST http.status=201`},
+		{"Error", WithObject("user", failing), `"user":{"id":"42"},"userError":"incomplete"`, ` user.id=42 userError=incomplete`},
+		{"Escaped keys", WithGroup("a b", WithString("c=d", "x")), `"a b":{"c=d":"x"}`, ` a_b.c_d=x`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := []Data{tt.field}
+			if got := string(NewJSONEncoder(EncoderConfig{}).EncodeFields(nil, fields)); got != ","+tt.json {
+				t.Errorf("Expected JSON %q, got %q", ","+tt.json, got)
+			}
+			if got := string(NewLogfmtEncoder(EncoderConfig{}).EncodeFields(nil, fields)); got != tt.logfmt {
+				t.Errorf("Expected logfmt %q, got %q", tt.logfmt, got)
+			}
+		})
+	}
+}
+
+func TestObjectWithLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false)).
+		With(WithGroup("service", WithString("name", "api"), WithString("version", "1.2")))
+
+	logger.InfoS(WithObject("http", request{method: "GET", status: 200}), WithDuration("elapsed", time.Millisecond))
+	want := `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","service":{"name":"api","version":"1.2"},` +
+		`"http":{"method":"GET","status":200},"elapsed":1000000}` + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	logger.Info("started")
+	if want := "2025-09-25T13:20:18.524Z [INFO] ▶ started service.name=api service.version=1.2\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestObjectLazyEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	calls := 0
+	obj := ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+		calls++
+		enc.AddField(WithBool("ok", true))
+		return nil
+	})
+	logger := New(WarnLevel, &buf)
+
+	logger.InfoS(WithObject("obj", obj))
+	if calls != 0 {
+		t.Errorf("Objects should not be encoded for filtered entries, got %d calls", calls)
+	}
+	logger.WarnS(WithObject("obj", obj))
+	if calls != 1 || !strings.Contains(buf.String(), `"obj":{"ok":true}`) {
+		t.Errorf("Expected one call and a nested object, got %d calls: %s", calls, buf.String())
+	}
+}
+
+func TestObjectSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+	logger := New(InfoLevel, nil, OptionSlogSink(sink), OptionIncludeFileInfo(false))
+
+	logger.InfoS(WithObject("http", request{method: "GET", status: 200}))
+	if want := `{"level":"INFO","msg":"","http":{"method":"GET","status":200}}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestObjectPanicError(t *testing.T) {
+	err := &PanicError{Fields: []Data{WithGroup("http", WithInt("status", 500))}}
+	if err.Error() != "http.status=500" {
+		t.Errorf("Expected flattened fields, got %q", err.Error())
+	}
+}
@@ -0,0 +1,106 @@
+package log
+
+import (
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Option configures a Logger created with New.
+type Option func(*logger)
+
+// New creates a Logger that writes entries at or above level to out.
+// Each Logger owns its output and configuration; changing one does not
+// affect the package-level logger or any other Logger.
+func New(level Level, out io.Writer, opts ...Option) Logger {
+	l := newLogger(level, out)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// newLogger creates a root logger with the default configuration, which
+// writes plain entries as text and structured entries as JSON (FormatMixed).
+func newLogger(level Level, out io.Writer) *logger {
+	atomicLevel := NewAtomicLevel(level)
+	l := &logger{
+		cores:           &atomic.Pointer[coreSet]{},
+		encoderConfig:   &EncoderConfig{},
+		level:           newLevelNode(atomicLevel),
+		includeFileInfo: &atomic.Bool{},
+		now:             time.Now,
+		hooks:           &atomic.Pointer[hookTable]{},
+		errorOutput:     os.Stderr,
+		exit:            &atomic.Pointer[func(code int)]{},
+		development:     &atomic.Bool{},
+	}
+	l.includeFileInfo.Store(true)
+	exit := os.Exit
+	l.exit.Store(&exit)
+	l.cores.Store(l.newCores(FormatMixed, atomicLevel, NewWriterSink(out)))
+	return l
+}
+
+// OptionLevel sets the minimum level of the logger.
+func OptionLevel(level Level) Option {
+	return func(l *logger) {
+		l.level.set(level)
+	}
+}
+
+// OptionAtomicLevel makes the logger use level as its minimum level.
+// Loggers sharing an AtomicLevel change level together.
+func OptionAtomicLevel(level *AtomicLevel) Option {
+	return func(l *logger) {
+		l.level.level.Store(level)
+		if l.encoderConfig != nil {
+			current := l.cores.Load()
+			l.cores.Store(l.newCores(current.format, level, current.core.sink))
+		}
+	}
+}
+
+// OptionOutput sets the output destination of the logger.
+func OptionOutput(out io.Writer) Option {
+	return func(l *logger) {
+		l.SetOutput(out)
+	}
+}
+
+// OptionIncludeFileInfo sets whether file and line information is included in entries.
+func OptionIncludeFileInfo(include bool) Option {
+	return func(l *logger) {
+		l.includeFileInfo.Store(include)
+	}
+}
+
+// OptionStacktrace makes entries at level or more severe carry the stack
+// trace of the goroutine that logged them. JSON and logfmt entries write it
+// under the Stacktrace header key, text and console entries on the lines
+// following the entry.
+func OptionStacktrace(level Level) Option {
+	return func(l *logger) {
+		l.stackLevel = NewAtomicLevel(level)
+	}
+}
+
+// OptionTimeFormat sets the layout used to format entry timestamps.
+// The layout follows the rules of time.Time.Format.
+func OptionTimeFormat(layout string) Option {
+	return func(l *logger) {
+		if l.encoderConfig != nil {
+			l.encoderConfig.TimeFormat = layout
+			l.buildCores()
+		}
+	}
+}
+
+// OptionClock sets the function used to obtain entry timestamps.
+// It is mostly useful to get deterministic output in tests.
+func OptionClock(now func() time.Time) Option {
+	return func(l *logger) {
+		l.now = now
+	}
+}
@@ -0,0 +1,145 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fixedClock() time.Time {
+	return time.Date(2025, 9, 25, 13, 20, 18, 524000000, time.UTC)
+}
+
+func TestNew(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(WarnLevel, &buf, OptionClock(fixedClock))
+
+	logger.Info("filtered")
+	if buf.Len() > 0 {
+		t.Errorf("Info should be filtered at Warn level, got: %s", buf.String())
+	}
+
+	logger.Warn("warn msg")
+	output := buf.String()
+	if !strings.HasPrefix(output, "2025-09-25T13:20:18.524Z [WARN] (log_options_test.go:") ||
+		!strings.HasSuffix(output, ") ▶ warn msg\n") {
+		t.Errorf("Unexpected text output: %q", output)
+	}
+
+	buf.Reset()
+	logger.ErrorS(WithString("user", "john"))
+	output = buf.String()
+	if !strings.HasPrefix(output, `{"timestamp":"2025-09-25T13:20:18.524Z","level":"ERROR","caller":"log_options_test.go:`) ||
+		!strings.HasSuffix(output, `,"user":"john"}`+"\n") {
+		t.Errorf("Unexpected structured output: %q", output)
+	}
+}
+
+func TestNewIsIndependent(t *testing.T) {
+	stdBuf, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	var buf1, buf2 bytes.Buffer
+	logger1 := New(InfoLevel, &buf1)
+	logger2 := New(InfoLevel, &buf2)
+
+	logger1.Info("one")
+	logger2.SetLevel(ErrorLevel)
+	logger2.Info("two")
+
+	if !strings.Contains(buf1.String(), "one") {
+		t.Errorf("Expected first logger output, got: %s", buf1.String())
+	}
+	if buf2.Len() > 0 {
+		t.Errorf("Second logger level should be independent, got: %s", buf2.String())
+	}
+	if stdBuf.Len() > 0 {
+		t.Errorf("Default logger should not receive output, got: %s", stdBuf.String())
+	}
+}
+
+func TestOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		logFn    func(Logger)
+		expected string
+	}{
+		{
+			"OptionLevel",
+			[]Option{OptionLevel(DebugLevel)},
+			func(l Logger) { l.Debug("debug msg") },
+			"[DEBUG]",
+		},
+		{
+			"OptionIncludeFileInfo",
+			[]Option{OptionIncludeFileInfo(false)},
+			func(l Logger) { l.Infof("user %s", "john") },
+			"2025-09-25T13:20:18.524Z [INFO] ▶ user john\n",
+		},
+		{
+			"OptionTimeFormat",
+			[]Option{OptionTimeFormat(time.Kitchen)},
+			func(l Logger) { l.Info("msg") },
+			"1:20PM [INFO]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := New(InfoLevel, &buf, append([]Option{OptionClock(fixedClock)}, tt.opts...)...)
+			tt.logFn(logger)
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Expected %q in output: %q", tt.expected, buf.String())
+			}
+		})
+	}
+
+	t.Run("OptionOutput", func(t *testing.T) {
+		var buf1, buf2 bytes.Buffer
+		logger := New(InfoLevel, &buf1, OptionOutput(&buf2))
+		logger.Info("msg")
+		if buf1.Len() > 0 || buf2.Len() == 0 {
+			t.Errorf("Expected output only in the optional writer, got %q and %q", buf1.String(), buf2.String())
+		}
+	})
+}
+
+func TestOptionStacktrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionStacktrace(ErrorLevel), OptionFormat(FormatJSON), OptionIncludeFileInfo(false))
+	// The stacks start at the closures below, which log
+	const top = "github.com/nszilard/log.TestOptionStacktrace.func"
+
+	stack := func() string {
+		t.Helper()
+		var entry struct{ Stacktrace string }
+		if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+			t.Fatalf("Invalid JSON %s: %v", buf.String(), err)
+		}
+		buf.Reset()
+		return entry.Stacktrace
+	}
+
+	logger.Warn("below the stack level")
+	if s := stack(); s != "" {
+		t.Errorf("Expected no stack below ErrorLevel, got %q", s)
+	}
+
+	for name, log := range map[string]func(){
+		"Error":    func() { logger.Error("failed") },
+		"ErrorS":   func() { logger.ErrorS(WithString("k", "v")) },
+		"ErrorCtx": func() { logger.ErrorCtx(t.Context(), "failed") },
+		"Child":    func() { logger.Named("db").With(WithInt("n", 1)).Errorf("failed %d", 1) },
+		"Slog":     func() { slog.New(NewSlogHandler(logger)).Error("failed") },
+	} {
+		log()
+		if s := stack(); !strings.HasPrefix(s, top) || !strings.Contains(s, "/log_options_test.go:") {
+			t.Errorf("%s: expected a stack starting in this test, got %q", name, s)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package log
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/nszilard/log/internal"
+)
+
+// PanicError is the value the Panic methods panic with. It carries the entry
+// that was logged, so that recover handlers can log or report it again.
+type PanicError struct {
+	Level   Level
+	Message string
+	Fields  []Data
+	Caller  string // file:line of the call that panicked
+}
+
+// Error returns the message, or the fields as key=value pairs for
+// structured entries without a message.
+func (e *PanicError) Error() string {
+	if e.Message != "" || len(e.Fields) == 0 {
+		return e.Message
+	}
+	buf := appendFlatFields(make([]byte, 0, 64), e.Fields, textStyle, defaultFieldEncoding)
+	if len(buf) == 0 {
+		// Empty groups write nothing
+		return e.Message
+	}
+	return string(buf[1:])
+}
+
+// newPanicError creates the panic value for an entry, recording the caller
+// of the function that calls newPanicError.
+func newPanicError(level Level, msg string, fields []Data) *PanicError {
+	file, line := internal.Caller(2)
+	return &PanicError{
+		Level:   level,
+		Message: msg,
+		Fields:  fields,
+		Caller:  file + ":" + strconv.Itoa(line),
+	}
+}
+
+// SetDevelopment sets whether the default logger is in development mode, in
+// which the DPanic methods panic. It is safe to call while other goroutines
+// are logging.
+func SetDevelopment(development bool) {
+	std.development.Store(development)
+}
+
+// OptionDevelopment sets whether the logger is in development mode, in which
+// the DPanic methods panic.
+func OptionDevelopment(development bool) Option {
+	return func(l *logger) {
+		l.development.Store(development)
+	}
+}
+
+// DPanic logs a message at ErrorLevel. In development mode it then panics
+// with a *PanicError.
+func DPanic(v ...any) {
+	msg := internal.Sprint(v...)
+	if std.enabled(ErrorLevel) {
+		std.write(ErrorLevel, msg)
+	}
+	if std.development.Load() {
+		panic(newPanicError(ErrorLevel, msg, nil))
+	}
+}
+
+// DPanicf logs a formatted message at ErrorLevel. In development mode it then
+// panics with a *PanicError.
+func DPanicf(format string, v ...any) {
+	msg := internal.Sprintf(format, v...)
+	if std.enabled(ErrorLevel) {
+		std.write(ErrorLevel, msg)
+	}
+	if std.development.Load() {
+		panic(newPanicError(ErrorLevel, msg, nil))
+	}
+}
+
+// DPanicS logs a structured message at ErrorLevel. In development mode it then
+// panics with a *PanicError carrying the fields.
+func DPanicS(fields ...Data) {
+	std.logStructured(ErrorLevel, "", fields)
+	if std.development.Load() {
+		panic(newPanicError(ErrorLevel, "", fields))
+	}
+}
+
+// DPanicCtx logs a message with the fields carried by ctx at ErrorLevel. In
+// development mode it then panics with a *PanicError.
+func DPanicCtx(ctx context.Context, msg string, fields ...Data) {
+	std.logCtx(ctx, ErrorLevel, msg, fields)
+	if std.development.Load() {
+		panic(newPanicError(ErrorLevel, msg, withContextFields(ctx, fields)))
+	}
+}
+
+// DPanic logs a message at ErrorLevel. In development mode it then panics
+// with a *PanicError.
+func (l *logger) DPanic(v ...any) {
+	msg := internal.Sprint(v...)
+	if l.enabled(ErrorLevel) {
+		l.write(ErrorLevel, msg)
+	}
+	if l.development.Load() {
+		panic(newPanicError(ErrorLevel, msg, nil))
+	}
+}
+
+// DPanicf logs a formatted message at ErrorLevel. In development mode it then
+// panics with a *PanicError.
+func (l *logger) DPanicf(format string, v ...any) {
+	msg := internal.Sprintf(format, v...)
+	if l.enabled(ErrorLevel) {
+		l.write(ErrorLevel, msg)
+	}
+	if l.development.Load() {
+		panic(newPanicError(ErrorLevel, msg, nil))
+	}
+}
+
+// DPanicS logs a structured message at ErrorLevel. In development mode it then
+// panics with a *PanicError carrying the fields.
+func (l *logger) DPanicS(fields ...Data) {
+	l.logStructured(ErrorLevel, "", fields)
+	if l.development.Load() {
+		panic(newPanicError(ErrorLevel, "", fields))
+	}
+}
+
+// DPanicCtx logs a message with the fields carried by ctx at ErrorLevel. In
+// development mode it then panics with a *PanicError.
+func (l *logger) DPanicCtx(ctx context.Context, msg string, fields ...Data) {
+	l.logCtx(ctx, ErrorLevel, msg, fields)
+	if l.development.Load() {
+		panic(newPanicError(ErrorLevel, msg, withContextFields(ctx, fields)))
+	}
+}
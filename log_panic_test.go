@@ -0,0 +1,129 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// recoverPanicError runs fn and returns the *PanicError it panics with, or nil.
+func recoverPanicError(t *testing.T, fn func()) (pe *PanicError) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			var ok bool
+			if pe, ok = r.(*PanicError); !ok {
+				t.Fatalf("Expected *PanicError, got %T: %v", r, r)
+			}
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestPanicError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(DebugLevel, &buf)
+
+	pe := recoverPanicError(t, func() { logger.Panicf("disk %s", "full") })
+	if pe == nil {
+		t.Fatal("Expected panic")
+	}
+	if pe.Level != PanicLevel || pe.Message != "disk full" || pe.Error() != "disk full" {
+		t.Errorf("Unexpected panic value: %#v", pe)
+	}
+	if !strings.HasPrefix(pe.Caller, "log_panic_test.go:") {
+		t.Errorf("Expected caller in this file, got %q", pe.Caller)
+	}
+
+	pe = recoverPanicError(t, func() { logger.PanicS(WithString("db", "main"), WithInt("retries", 3)) })
+	if pe == nil || len(pe.Fields) != 2 || pe.Error() != "db=main retries=3" {
+		t.Errorf("Unexpected structured panic value: %#v", pe)
+	}
+	if !strings.Contains(buf.String(), `"db":"main","retries":3`) {
+		t.Errorf("Expected entry before panic: %s", buf.String())
+	}
+}
+
+func TestPanicErrorEmptyGroup(t *testing.T) {
+	logger := New(DebugLevel, &bytes.Buffer{})
+
+	pe := recoverPanicError(t, func() { logger.PanicS(WithGroup("g")) })
+	if pe == nil {
+		t.Fatal("Expected panic")
+	}
+	if pe.Error() != "" {
+		t.Errorf("Expected empty error for an empty group, got %q", pe.Error())
+	}
+}
+
+func TestDPanic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf)
+
+	pe := recoverPanicError(t, func() {
+		logger.DPanic("unexpected")
+		logger.DPanicf("unexpected %d", 2)
+		logger.DPanicS(WithString("state", "bad"))
+		logger.DPanicCtx(ContextWithFields(context.Background(), WithString("request_id", "abc")), "unexpected")
+	})
+	if pe != nil {
+		t.Fatalf("Expected no panic outside development mode, got %v", pe)
+	}
+	if got := strings.Count(buf.String(), "ERROR"); got != 4 {
+		t.Errorf("Expected 4 entries at ERROR, got %d: %s", got, buf.String())
+	}
+
+	dev := New(InfoLevel, &buf, OptionDevelopment(true))
+	tests := []struct {
+		name  string
+		logFn func()
+	}{
+		{"DPanic", func() { dev.DPanic("unexpected") }},
+		{"DPanicf", func() { dev.DPanicf("unexpected %d", 2) }},
+		{"DPanicS", func() { dev.DPanicS(WithString("state", "bad")) }},
+		{"DPanicCtx", func() { dev.DPanicCtx(context.Background(), "unexpected") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if pe := recoverPanicError(t, tt.logFn); pe == nil || pe.Level != ErrorLevel {
+				t.Errorf("Expected *PanicError at ErrorLevel, got %#v", pe)
+			}
+		})
+	}
+}
+
+func TestDPanicDefaultLogger(t *testing.T) {
+	_, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	SetDevelopment(true)
+	defer SetDevelopment(false)
+
+	if pe := recoverPanicError(t, func() { DPanic("unexpected") }); pe == nil {
+		t.Error("Expected panic in development mode")
+	}
+}
+
+func TestConcurrentDevelopment(t *testing.T) {
+	_, cleanup := setupTestLogger(t, InfoLevel)
+	defer cleanup()
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 100 {
+			SetDevelopment(i%2 == 0)
+		}
+	})
+	wg.Go(func() {
+		for range 100 {
+			func() {
+				defer func() { _ = recover() }()
+				DPanic("unexpected")
+			}()
+		}
+	})
+	wg.Wait()
+}
@@ -0,0 +1,258 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nszilard/log/internal"
+)
+
+// slogHandler implements slog.Handler on top of a logger.
+type slogHandler struct {
+	logger *logger
+	groups []slogGroup // Groups opened with WithGroup, innermost last
+}
+
+// slogGroup is a group opened with WithGroup and the fields added to it since.
+type slogGroup struct {
+	name   string
+	fields []Data
+}
+
+// NewSlogHandler returns a slog.Handler that writes records through l, using
+// its level, output, name and bound fields. Records are written as structured
+// entries with a "msg" field, attributes are converted to typed fields and
+// groups to objects, nested in JSON and flattened into dotted keys in the
+// other formats.
+//
+// Only loggers created by this package can back a handler; for any other
+// Logger, such as NoopLogger, the returned handler discards every record.
+func NewSlogHandler(l Logger) slog.Handler {
+	lg, _ := l.(*logger)
+	return &slogHandler{logger: lg}
+}
+
+// slogLevel maps a slog.Level onto the closest Level. Levels above
+// slog.LevelError map to ErrorLevel, as slog has no notion of fatal or panic.
+func slogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return DebugLevel
+	case level < slog.LevelWarn:
+		return InfoLevel
+	case level < slog.LevelError:
+		return WarnLevel
+	default:
+		return ErrorLevel
+	}
+}
+
+// Enabled reports whether the logger writes records at the given level.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger != nil && h.logger.enabled(slogLevel(level))
+}
+
+// Handle writes the record.
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.logger == nil {
+		return nil
+	}
+
+	fields := make([]Data, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		fields = appendAttr(fields, a)
+		return true
+	})
+	// Nest the fields in the open groups, leaving out the empty ones
+	for i := len(h.groups) - 1; i >= 0; i-- {
+		g := h.groups[i]
+		members := append(g.fields[:len(g.fields):len(g.fields)], fields...)
+		fields = nil
+		if len(members) > 0 {
+			fields = []Data{WithGroup(g.name, members...)}
+		}
+	}
+
+	pc := r.PC
+	if !h.logger.includeFileInfo.Load() {
+		pc = 0
+	}
+	e := getEntry()
+	e.Level, e.Time, e.Message, e.Fields = slogLevel(r.Level), r.Time, r.Message, fields
+	if e.Time.IsZero() {
+		e.Time = h.logger.now()
+	}
+	if pc != 0 {
+		e.File, e.Line = internal.CallerFromPC(pc)
+	}
+	if h.logger.stackLevel != nil && h.logger.stackLevel.Enabled(e.Level) {
+		// Start at the caller of the slog.Logger method when it is known
+		e.Stack = stacktrace(1, r.PC)
+	}
+	cores := h.logger.cores.Load()
+	h.logger.dispatch(ctx, cores.structuredCore, cores.structuredContext, e, pc)
+	putEntry(e)
+	return nil
+}
+
+// WithAttrs returns a handler with the attributes added to the innermost open
+// group, or bound to its logger when no group is open.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if h.logger == nil || len(attrs) == 0 {
+		return h
+	}
+
+	var fields []Data
+	n := len(h.groups)
+	if n > 0 {
+		fields = h.groups[n-1].fields
+		fields = fields[:len(fields):len(fields)]
+	}
+	for _, a := range attrs {
+		fields = appendAttr(fields, a)
+	}
+	if n == 0 {
+		return &slogHandler{logger: h.logger.With(fields...).(*logger)}
+	}
+	groups := append([]slogGroup(nil), h.groups...)
+	groups[n-1].fields = fields
+	return &slogHandler{logger: h.logger, groups: groups}
+}
+
+// WithGroup returns a handler that nests later attributes in a group named name.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := append(h.groups[:len(h.groups):len(h.groups)], slogGroup{name: name})
+	return &slogHandler{logger: h.logger, groups: groups}
+}
+
+// appendAttr converts a to typed fields and appends them to fields.
+func appendAttr(fields []Data, a slog.Attr) []Data {
+	if a.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	v := a.Value.Resolve()
+	key := a.Key
+	switch v.Kind() {
+	case slog.KindString:
+		return append(fields, WithString(key, v.String()))
+	case slog.KindInt64:
+		return append(fields, WithInt(key, v.Int64()))
+	case slog.KindUint64:
+		return append(fields, WithUint64(key, v.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, WithFloat(key, v.Float64()))
+	case slog.KindBool:
+		return append(fields, WithBool(key, v.Bool()))
+	case slog.KindDuration:
+		return append(fields, WithDuration(key, v.Duration()))
+	case slog.KindTime:
+		return append(fields, WithTime(key, v.Time()))
+	case slog.KindGroup:
+		var group []Data
+		for _, ga := range v.Group() {
+			group = appendAttr(group, ga)
+		}
+		if len(group) == 0 {
+			return fields
+		}
+		if key == "" {
+			// Groups without a key are inlined
+			return append(fields, group...)
+		}
+		return append(fields, WithGroup(key, group...))
+	default:
+		if err, ok := v.Any().(error); ok {
+			return append(fields, WithError(key, err))
+		}
+		return append(fields, WithAny(key, v.Any()))
+	}
+}
+
+// OptionSlogSink makes the logger forward every entry to h as a slog.Record
+// instead of writing it to the output. Records carry the caller's PC (unless
+// file information is disabled), the level mapped onto slog levels, the
+// formatted message and the fields as attributes. Structured entries have an
+// empty message. Bound fields are passed to h.WithAttrs and the logger name
+// is added as a "logger" attribute and stack traces as a "stacktrace"
+// attribute. Records logged with the *Ctx methods are handled with their
+// context, so handlers can read trace data from it.
+func OptionSlogSink(h slog.Handler) Option {
+	return func(l *logger) {
+		l.slogSink = h
+	}
+}
+
+// toSlogLevel maps a Level onto a slog.Level. The built-in levels map onto
+// the slog levels of the same name, with FatalLevel and PanicLevel above
+// slog.LevelError; registered levels are placed proportionally between them.
+func toSlogLevel(level Level) slog.Level {
+	return slog.LevelError + 8 - slog.Level(int(level)*4/10)
+}
+
+// toSlogAttr converts a field to a slog.Attr.
+func toSlogAttr(field Data) slog.Attr {
+	switch field.Type {
+	case StringType:
+		return slog.String(field.Key, field.String)
+	case IntType:
+		return slog.Int64(field.Key, field.Integer)
+	case FloatType:
+		return slog.Float64(field.Key, field.Float)
+	case BoolType:
+		return slog.Bool(field.Key, field.Bool)
+	case DurationType:
+		return slog.Duration(field.Key, time.Duration(field.Integer))
+	case UintType:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case Float32Type:
+		return slog.Float64(field.Key, field.Float)
+	case BytesType:
+		return slog.String(field.Key, string(internal.AppendBytes(nil, toInternalField(&field))))
+	case StringerType:
+		return slog.String(field.Key, internal.StringerValue(field.Interface))
+	case ComplexType:
+		return slog.String(field.Key, string(internal.AppendComplex(nil, toInternalField(&field))))
+	case NilType:
+		return slog.Any(field.Key, nil)
+	case LazyType:
+		return slog.Any(field.Key, lazyValuer(field))
+	}
+	if m, ok := objectMarshaler(toInternalField(&field)); ok {
+		fields, err := objectFields(m)
+		attrs := make([]any, 0, len(fields)+1)
+		for _, f := range fields {
+			attrs = append(attrs, toSlogAttr(f))
+		}
+		if err != nil {
+			attrs = append(attrs, slog.String("error", err.Error()))
+		}
+		return slog.Group(field.Key, attrs...)
+	}
+	return slog.Any(field.Key, field.Interface)
+}
+
+// forward sends an entry logged at pc to the slog sink of the logger, with
+// the context given to the *Ctx method that logged it.
+func (l *logger) forward(ctx context.Context, e *Entry, pc uintptr) {
+	slogLevel := toSlogLevel(e.Level)
+	if !l.slogSink.Enabled(ctx, slogLevel) {
+		return
+	}
+
+	r := slog.NewRecord(e.Time, slogLevel, e.Message, pc)
+	if l.name != "" {
+		r.AddAttrs(slog.String("logger", l.name))
+	}
+	for _, field := range e.Fields {
+		r.AddAttrs(toSlogAttr(field))
+	}
+	if e.Stack != "" {
+		r.AddAttrs(slog.String("stacktrace", e.Stack))
+	}
+	_ = l.slogSink.Handle(ctx, r)
+}
@@ -0,0 +1,314 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+type slogPoint struct{ X, Y int }
+
+func (p slogPoint) LogValue() slog.Value {
+	return slog.GroupValue(slog.Int("x", p.X), slog.Int("y", p.Y))
+}
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(New(InfoLevel, &buf)))
+
+	logger.Info("user logged in",
+		"user", "john",
+		slog.Int("age", 30),
+		slog.Uint64("big", 1<<63),
+		slog.Float64("score", 95.5),
+		slog.Bool("admin", true),
+		slog.Duration("elapsed", time.Second),
+		slog.Time("at", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)),
+		slog.Any("err", errors.New("boom")),
+		slog.Group("req", slog.String("method", "GET"), slog.Int("status", 200)),
+		slog.Group("empty"),
+		slog.Any("point", slogPoint{1, 2}),
+		slog.Attr{},
+	)
+
+	output := buf.String()
+	if !strings.HasPrefix(output, `{"timestamp":"`) || !strings.Contains(output, `","level":"INFO","caller":"log_slog_test.go:`) {
+		t.Errorf("Unexpected header: %s", output)
+	}
+	expected := `,"msg":"user logged in","user":"john","age":30,"big":9223372036854775808,"score":95.5,"admin":true,` +
+		`"elapsed":1000000000,"at":"2023-01-01T12:00:00Z","err":"boom","req":{"method":"GET","status":200},"point":{"x":1,"y":2}}` + "\n"
+	if !strings.HasSuffix(output, expected) {
+		t.Errorf("Expected suffix %s in output: %s", expected, output)
+	}
+	if !json.Valid([]byte(output)) {
+		t.Errorf("Invalid JSON: %s", output)
+	}
+}
+
+func TestSlogHandlerUint64(t *testing.T) {
+	for _, u := range []uint64{7, 1 << 63, math.MaxUint64} {
+		fields := appendAttr(nil, slog.Uint64("n", u))
+		if len(fields) != 1 || fields[0].Type != UintType || uint64(fields[0].Integer) != u {
+			t.Errorf("Expected a UintType field for %d, got %+v", u, fields)
+		}
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	tests := []struct {
+		level    slog.Level
+		expected Level
+	}{
+		{slog.LevelDebug - 4, DebugLevel},
+		{slog.LevelDebug, DebugLevel},
+		{slog.LevelInfo, InfoLevel},
+		{slog.LevelInfo + 2, InfoLevel},
+		{slog.LevelWarn, WarnLevel},
+		{slog.LevelError, ErrorLevel},
+		{slog.LevelError + 4, ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		if got := slogLevel(tt.level); got != tt.expected {
+			t.Errorf("slogLevel(%v) = %v, want %v", tt.level, got, tt.expected)
+		}
+	}
+
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(New(WarnLevel, &buf)))
+	logger.Info("filtered")
+	if buf.Len() > 0 {
+		t.Errorf("Info should be filtered at Warn level: %s", buf.String())
+	}
+	logger.Error("written")
+	if !strings.Contains(buf.String(), `"level":"ERROR"`) {
+		t.Errorf("Expected ERROR entry: %s", buf.String())
+	}
+}
+
+func TestSlogHandlerWithAttrsAndGroups(t *testing.T) {
+	var buf bytes.Buffer
+	base := New(InfoLevel, &buf, OptionIncludeFileInfo(false)).Named("api").With(WithString("service", "users"))
+	logger := slog.New(NewSlogHandler(base)).
+		With("request_id", "abc").
+		WithGroup("http").
+		With(slog.String("method", "GET")).
+		WithGroup("")
+
+	logger.Info("handled", "status", 200)
+	expected := `"level":"INFO","logger":"api","msg":"handled","service":"users","request_id":"abc","http":{"method":"GET","status":200}}` + "\n"
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("Expected suffix %s in output: %s", expected, buf.String())
+	}
+}
+
+func TestSlogHandlerNoop(t *testing.T) {
+	handler := NewSlogHandler(NoopLogger{})
+	if handler.Enabled(t.Context(), slog.LevelError) {
+		t.Error("Handler backed by NoopLogger should not be enabled")
+	}
+	logger := slog.New(handler).With("k", "v").WithGroup("g")
+	logger.Error("discarded")
+}
+
+func TestSlogHandlerRecordTime(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSlogHandler(New(InfoLevel, &buf))
+
+	record := slog.NewRecord(fixedClock().In(time.FixedZone("CET", 3600)), slog.LevelInfo, "msg", 0)
+	if err := handler.Handle(t.Context(), record); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+	expected := `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","msg":"msg"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, buf.String())
+	}
+}
+
+func TestSlogHandlerConformance(t *testing.T) {
+	var buf bytes.Buffer
+	handler := NewSlogHandler(New(DebugLevel, &buf, OptionFormat(FormatJSON), OptionHeaderKeys(HeaderKeys{Time: slog.TimeKey})))
+
+	err := slogtest.TestHandler(handler, func() []map[string]any {
+		var entries []map[string]any
+		for line := range bytes.Lines(buf.Bytes()) {
+			var m map[string]any
+			if err := json.Unmarshal(line, &m); err != nil {
+				t.Fatalf("Invalid JSON %s: %v", line, err)
+			}
+			entries = append(entries, m)
+		}
+		return entries
+	})
+	if err == nil {
+		return
+	}
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		// Records without a time are stamped with the logger's clock
+		if !strings.Contains(e.Error(), "zero Record.Time") {
+			t.Error(e)
+		}
+	}
+}
+
+func newSlogSinkLogger(buf *bytes.Buffer, opts ...Option) Logger {
+	handler := slog.NewJSONHandler(buf, &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug - 4,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			switch a.Key {
+			case slog.TimeKey:
+				return slog.Attr{}
+			case slog.SourceKey:
+				source, ok := a.Value.Any().(*slog.Source)
+				if !ok || source.File == "" {
+					return slog.Attr{}
+				}
+				return slog.String(slog.SourceKey, source.File[strings.LastIndexByte(source.File, '/')+1:])
+			}
+			return a
+		},
+	})
+	return New(DebugLevel, &bytes.Buffer{}, append([]Option{OptionSlogSink(handler), OptionExitFunc(func(int) {})}, opts...)...)
+}
+
+func TestSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newSlogSinkLogger(&buf)
+
+	tests := []struct {
+		name     string
+		logFn    func()
+		expected string
+	}{
+		{
+			"Infof",
+			func() { logger.Infof("user %s logged in", "john") },
+			`{"level":"INFO","source":"log_slog_test.go","msg":"user john logged in"}`,
+		},
+		{
+			"InfoS",
+			func() {
+				logger.InfoS(
+					WithString("user", "john"),
+					WithInt("age", 30),
+					WithFloat("score", 95.5),
+					WithBool("admin", true),
+					WithError("err", errors.New("boom")),
+					WithDuration("elapsed", time.Second),
+					WithTime("at", time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)),
+					WithAny("tags", []string{"a"}),
+				)
+			},
+			`{"level":"INFO","source":"log_slog_test.go","msg":"","user":"john","age":30,"score":95.5,"admin":true,` +
+				`"err":"boom","elapsed":1000000000,"at":"2023-01-01T12:00:00Z","tags":["a"]}`,
+		},
+		{
+			"Debug",
+			func() { logger.Debug("details") },
+			`{"level":"DEBUG","source":"log_slog_test.go","msg":"details"}`,
+		},
+		{
+			"Fatal",
+			func() { logger.Fatal("stop") },
+			`{"level":"ERROR+4","source":"log_slog_test.go","msg":"stop"}`,
+		},
+		{
+			"With and Named",
+			func() { logger.Named("db").With(WithString("tenant", "acme")).Warn("slow") },
+			`{"level":"WARN","source":"log_slog_test.go","msg":"slow","tenant":"acme","logger":"db"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.logFn()
+			if got := strings.TrimSpace(buf.String()); got != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSlogSinkFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newSlogSinkLogger(&buf, OptionLevel(WarnLevel), OptionIncludeFileInfo(false))
+
+	logger.Info("filtered")
+	logger.InfoS(WithString("filtered", "yes"))
+	if buf.Len() > 0 {
+		t.Errorf("Expected no output below Warn level, got: %s", buf.String())
+	}
+
+	logger.Error("written")
+	expected := `{"level":"ERROR","msg":"written"}`
+	if got := strings.TrimSpace(buf.String()); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+func TestSlogHandlerWithSlogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewSlogHandler(newSlogSinkLogger(&buf)))
+
+	logger.Info("hello", "user", "john")
+	expected := `{"level":"INFO","source":"log_slog_test.go","msg":"hello","user":"john"}`
+	if got := strings.TrimSpace(buf.String()); got != expected {
+		t.Errorf("Expected %s, got %s", expected, got)
+	}
+}
+
+type traceKey struct{}
+
+// traceHandler adds the trace ID carried by the context of a record.
+type traceHandler struct{ slog.Handler }
+
+func (h traceHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id, ok := ctx.Value(traceKey{}).(string); ok {
+		r.AddAttrs(slog.String("trace_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func TestSlogSinkContext(t *testing.T) {
+	var buf bytes.Buffer
+	sink := traceHandler{slog.NewJSONHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})}
+	logger := New(InfoLevel, &bytes.Buffer{}, OptionSlogSink(sink), OptionIncludeFileInfo(false))
+	ctx := context.WithValue(t.Context(), traceKey{}, "abc")
+
+	logger.InfoCtx(ctx, "handled")
+	slog.New(NewSlogHandler(logger)).InfoContext(ctx, "bridged")
+	logger.Info("untraced")
+
+	expected := `{"level":"INFO","msg":"handled","trace_id":"abc"}` + "\n" +
+		`{"level":"INFO","msg":"bridged","trace_id":"abc"}` + "\n" +
+		`{"level":"INFO","msg":"untraced"}` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Expected %s, got %s", expected, buf.String())
+	}
+}
+
+func TestSlogSinkStacktrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newSlogSinkLogger(&buf, OptionStacktrace(ErrorLevel), OptionIncludeFileInfo(false))
+
+	logger.Error("failed")
+	if !strings.Contains(buf.String(), `"stacktrace":"github.com/nszilard/log.TestSlogSinkStacktrace\n\t`) {
+		t.Errorf("Expected a stacktrace attribute, got %s", buf.String())
+	}
+}
@@ -1,36 +1,93 @@
 package log
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"time"
 
-// PanicS logs a structured message at PanicLevel and then panics.
+	"github.com/nszilard/log/internal"
+)
+
+// LogS logs a structured message at the given level, which may be a registered
+// level. Unlike PanicS and FatalS, it never panics or exits, whatever the level.
+func LogS(level Level, fields ...Data) {
+	std.logStructured(level, "", fields)
+}
+
+// PanicS logs a structured message at PanicLevel and then panics with a
+// *PanicError carrying the fields.
 func PanicS(fields ...Data) {
-	logStructured(PanicLevel, fields)
-	panic("panic")
+	std.logStructured(PanicLevel, "", fields)
+	panic(newPanicError(PanicLevel, "", fields))
 }
 
-// FatalS logs a structured message at FatalLevel.
+// FatalS logs a structured message at FatalLevel, then flushes the output, runs
+// the exit hooks and calls the exit function. Use LogS(FatalLevel, ...) to log
+// at FatalLevel without exiting.
 func FatalS(fields ...Data) {
-	logStructured(FatalLevel, fields)
+	std.logStructured(FatalLevel, "", fields)
+	std.terminate()
 }
 
 // ErrorS logs a structured message at ErrorLevel.
 func ErrorS(fields ...Data) {
-	logStructured(ErrorLevel, fields)
+	std.logStructured(ErrorLevel, "", fields)
 }
 
 // WarnS logs a structured message at WarnLevel.
 func WarnS(fields ...Data) {
-	logStructured(WarnLevel, fields)
+	std.logStructured(WarnLevel, "", fields)
 }
 
 // InfoS logs a structured message at InfoLevel.
 func InfoS(fields ...Data) {
-	logStructured(InfoLevel, fields)
+	std.logStructured(InfoLevel, "", fields)
 }
 
 // DebugS logs a structured message at DebugLevel.
 func DebugS(fields ...Data) {
-	logStructured(DebugLevel, fields)
+	std.logStructured(DebugLevel, "", fields)
+}
+
+// LogS logs a structured message at the given level, which may be a registered
+// level. Unlike PanicS and FatalS, it never panics or exits, whatever the level.
+func (l *logger) LogS(level Level, fields ...Data) {
+	l.logStructured(level, "", fields)
+}
+
+// PanicS logs a structured message at PanicLevel and then panics with a
+// *PanicError carrying the fields.
+func (l *logger) PanicS(fields ...Data) {
+	l.logStructured(PanicLevel, "", fields)
+	panic(newPanicError(PanicLevel, "", fields))
+}
+
+// FatalS logs a structured message at FatalLevel, then flushes the output, runs
+// the exit hooks and calls the exit function. Use LogS(FatalLevel, ...) to log
+// at FatalLevel without exiting.
+func (l *logger) FatalS(fields ...Data) {
+	l.logStructured(FatalLevel, "", fields)
+	l.terminate()
+}
+
+// ErrorS logs a structured message at ErrorLevel.
+func (l *logger) ErrorS(fields ...Data) {
+	l.logStructured(ErrorLevel, "", fields)
+}
+
+// WarnS logs a structured message at WarnLevel.
+func (l *logger) WarnS(fields ...Data) {
+	l.logStructured(WarnLevel, "", fields)
+}
+
+// InfoS logs a structured message at InfoLevel.
+func (l *logger) InfoS(fields ...Data) {
+	l.logStructured(InfoLevel, "", fields)
+}
+
+// DebugS logs a structured message at DebugLevel.
+func (l *logger) DebugS(fields ...Data) {
+	l.logStructured(DebugLevel, "", fields)
 }
 
 // WithString adds a string key-value pair to the structured logger
@@ -43,11 +100,64 @@ func WithInt(key string, val int64) Data {
 	return Data{Key: key, Type: IntType, Integer: val}
 }
 
+// WithInt8 adds an int8 key-value pair to the structured logger
+func WithInt8(key string, val int8) Data {
+	return Data{Key: key, Type: IntType, Integer: int64(val)}
+}
+
+// WithInt16 adds an int16 key-value pair to the structured logger
+func WithInt16(key string, val int16) Data {
+	return Data{Key: key, Type: IntType, Integer: int64(val)}
+}
+
+// WithInt32 adds an int32 key-value pair to the structured logger
+func WithInt32(key string, val int32) Data {
+	return Data{Key: key, Type: IntType, Integer: int64(val)}
+}
+
+// WithUint adds a uint key-value pair to the structured logger
+func WithUint(key string, val uint) Data {
+	return Data{Key: key, Type: UintType, Integer: int64(val)}
+}
+
+// WithUint8 adds a uint8 key-value pair to the structured logger
+func WithUint8(key string, val uint8) Data {
+	return Data{Key: key, Type: UintType, Integer: int64(val)}
+}
+
+// WithUint16 adds a uint16 key-value pair to the structured logger
+func WithUint16(key string, val uint16) Data {
+	return Data{Key: key, Type: UintType, Integer: int64(val)}
+}
+
+// WithUint32 adds a uint32 key-value pair to the structured logger
+func WithUint32(key string, val uint32) Data {
+	return Data{Key: key, Type: UintType, Integer: int64(val)}
+}
+
+// WithUint64 adds a uint64 key-value pair to the structured logger. Values
+// above math.MaxInt64 are written exactly.
+func WithUint64(key string, val uint64) Data {
+	return Data{Key: key, Type: UintType, Integer: int64(val)}
+}
+
 // WithFloat adds a float64 key-value pair to the structured logger
 func WithFloat(key string, val float64) Data {
 	return Data{Key: key, Type: FloatType, Float: val}
 }
 
+// WithFloat32 adds a float32 key-value pair to the structured logger, written
+// with the precision of a float32
+func WithFloat32(key string, val float32) Data {
+	return Data{Key: key, Type: Float32Type, Float: float64(val)}
+}
+
+// WithComplex adds a complex128 key-value pair to the structured logger,
+// written as a string such as "1+2i"
+func WithComplex(key string, val complex128) Data {
+	return Data{Key: key, Type: ComplexType, Float: real(val), Integer: int64(math.Float64bits(imag(val)))}
+}
+
 // WithBool adds a bool key-value pair to the structured logger
 func WithBool(key string, val bool) Data {
 	return Data{Key: key, Type: BoolType, Bool: val}
@@ -71,6 +181,146 @@ func WithTime(key string, val time.Time) Data {
 	return Data{Key: key, Type: TimeType, Interface: val}
 }
 
+// WithBytes adds a byte slice key-value pair to the structured logger,
+// written in standard base64
+func WithBytes(key string, val []byte) Data {
+	return Data{Key: key, Type: BytesType, Integer: internal.BytesBase64, Interface: val}
+}
+
+// WithHex adds a byte slice key-value pair to the structured logger, written
+// in lowercase hexadecimal
+func WithHex(key string, val []byte) Data {
+	return Data{Key: key, Type: BytesType, Integer: internal.BytesHex, Interface: val}
+}
+
+// WithStringer adds a fmt.Stringer key-value pair to the structured logger.
+// The String method is called only when the entry is written.
+func WithStringer(key string, val fmt.Stringer) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return Data{Key: key, Type: StringerType, Interface: val}
+}
+
+// WithStringPtr adds a *string key-value pair to the structured logger. A nil
+// pointer is written as null.
+func WithStringPtr(key string, val *string) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithString(key, *val)
+}
+
+// WithIntPtr adds a *int key-value pair to the structured logger. A nil
+// pointer is written as null.
+func WithIntPtr(key string, val *int) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithInt(key, int64(*val))
+}
+
+// WithInt64Ptr adds a *int64 key-value pair to the structured logger. A nil
+// pointer is written as null.
+func WithInt64Ptr(key string, val *int64) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithInt(key, *val)
+}
+
+// WithUint64Ptr adds a *uint64 key-value pair to the structured logger. A
+// nil pointer is written as null.
+func WithUint64Ptr(key string, val *uint64) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithUint64(key, *val)
+}
+
+// WithFloatPtr adds a *float64 key-value pair to the structured logger. A
+// nil pointer is written as null.
+func WithFloatPtr(key string, val *float64) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithFloat(key, *val)
+}
+
+// WithBoolPtr adds a *bool key-value pair to the structured logger. A nil
+// pointer is written as null.
+func WithBoolPtr(key string, val *bool) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithBool(key, *val)
+}
+
+// WithDurationPtr adds a *time.Duration key-value pair to the structured
+// logger. A nil pointer is written as null.
+func WithDurationPtr(key string, val *time.Duration) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithDuration(key, *val)
+}
+
+// WithTimePtr adds a *time.Time key-value pair to the structured logger. A
+// nil pointer is written as null.
+func WithTimePtr(key string, val *time.Time) Data {
+	if val == nil {
+		return Data{Key: key, Type: NilType}
+	}
+	return WithTime(key, *val)
+}
+
+// WithLazy adds a key-value pair whose value is computed by fn, only for
+// entries that pass the level check. Loggers created by With call fn for
+// every entry they write, so fn must be safe for concurrent use.
+func WithLazy(key string, fn func() any) Data {
+	return Data{Key: key, Type: LazyType, Interface: fn}
+}
+
+// WithLazyString is like WithLazy for a function computing a string.
+func WithLazyString(key string, fn func() string) Data {
+	return Data{Key: key, Type: LazyType, Interface: fn}
+}
+
+// WithObject adds an object that writes its own fields to the structured logger
+func WithObject(key string, val ObjectMarshaler) Data {
+	return Data{Key: key, Type: ObjectType, Interface: val}
+}
+
+// WithGroup nests fields under key in the structured logger
+func WithGroup(key string, fields ...Data) Data {
+	return Data{Key: key, Type: ObjectType, Interface: group(fields)}
+}
+
+// WithArray adds an array that writes its own elements to the structured logger
+func WithArray(key string, val ArrayMarshaler) Data {
+	return Data{Key: key, Type: ArrayType, Interface: val}
+}
+
+// WithStrings adds a string slice key-value pair to the structured logger
+func WithStrings(key string, vals []string) Data {
+	return Data{Key: key, Type: ArrayType, Interface: stringArray(vals)}
+}
+
+// WithInts adds an integer slice key-value pair to the structured logger
+func WithInts[T ~int | ~int8 | ~int16 | ~int32 | ~int64](key string, vals []T) Data {
+	return Data{Key: key, Type: ArrayType, Interface: intArray[T](vals)}
+}
+
+// WithFloats adds a float slice key-value pair to the structured logger
+func WithFloats[T ~float32 | ~float64](key string, vals []T) Data {
+	return Data{Key: key, Type: ArrayType, Interface: floatArray[T](vals)}
+}
+
+// WithDurations adds a time.Duration slice key-value pair to the structured logger
+func WithDurations(key string, vals []time.Duration) Data {
+	return Data{Key: key, Type: ArrayType, Interface: durationArray(vals)}
+}
+
 // WithAny adds an any key-value pair to the structured logger
 func WithAny(key string, val any) Data {
 	return Data{Key: key, Type: UnknownType, Interface: val}
@@ -2,6 +2,8 @@ package log
 
 import (
 	"errors"
+	"math"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -59,6 +61,97 @@ func TestStructuredFieldTypes(t *testing.T) {
 	}
 }
 
+type countingStringer struct {
+	calls *int
+}
+
+func (s *countingStringer) String() string {
+	*s.calls++
+	return "v" + strconv.Itoa(*s.calls)
+}
+
+func TestStructuredTypedFields(t *testing.T) {
+	var nilStringer *countingStringer
+	when := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	fields := []Data{
+		WithUint64("max", math.MaxUint64),
+		WithUint8("small", 7),
+		WithInt16("neg", -3),
+		WithFloat32("ratio", 0.1),
+		WithBytes("raw", []byte("hi!?")),
+		WithHex("id", []byte{0xde, 0xad}),
+		WithComplex("c", complex(1, -2.5)),
+		WithStringer("panicky", nilStringer),
+		WithTimePtr("at", &when),
+		WithIntPtr("missing", nil),
+	}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{
+			"JSON",
+			NewJSONEncoder(EncoderConfig{}),
+			`,"max":18446744073709551615,"small":7,"neg":-3,"ratio":0.1,"raw":"aGkhPw==","id":"dead","c":"1-2.5i",` +
+				`"panicky":"<panic: runtime error: invalid memory address or nil pointer dereference>","at":"2023-01-01T12:00:00Z","missing":null`,
+		},
+		{
+			"Text",
+			NewTextEncoder(EncoderConfig{}),
+			` max=18446744073709551615 small=7 neg=-3 ratio=0.1 raw="aGkhPw==" id=dead c=1-2.5i ` +
+				`panicky="<panic: runtime error: invalid memory address or nil pointer dereference>" at=2023-01-01T12:00:00Z missing=<nil>`,
+		},
+		{
+			"Logfmt",
+			NewLogfmtEncoder(EncoderConfig{}),
+			` max=18446744073709551615 small=7 neg=-3 ratio=0.1 raw="aGkhPw==" id=dead c=1-2.5i ` +
+				`panicky="<panic: runtime error: invalid memory address or nil pointer dereference>" at=2023-01-01T12:00:00Z missing=null`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.encoder.EncodeFields(nil, fields)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestStructuredComplexSigns(t *testing.T) {
+	tests := map[complex128]string{
+		complex(1, 2):            `"1+2i"`,
+		complex(0, math.Inf(1)):  `"0+Infi"`,
+		complex(0, math.Inf(-1)): `"0-Infi"`,
+		complex(-1, math.NaN()):  `"-1+NaNi"`,
+	}
+	for c, expected := range tests {
+		fields := []Data{WithComplex("c", c)}
+		if got := string(NewJSONEncoder(EncoderConfig{}).EncodeFields(nil, fields)); got != `,"c":`+expected {
+			t.Errorf("Expected %s for %v, got %s", expected, c, got)
+		}
+	}
+}
+
+func TestStructuredStringerLazy(t *testing.T) {
+	buf, cleanup := setupTestLogger(t, WarnLevel)
+	defer cleanup()
+
+	calls := 0
+	s := &countingStringer{calls: &calls}
+	InfoS(WithStringer("value", s))
+	if calls != 0 {
+		t.Errorf("String should not be called for filtered entries, got %d calls", calls)
+	}
+
+	WarnS(WithStringer("value", s), WithStringer("nil", nil))
+	if calls != 1 || !strings.Contains(buf.String(), `"value":"v1","nil":null`) {
+		t.Errorf("Expected one call, got %d: %s", calls, buf.String())
+	}
+}
+
 func TestStructuredEdgeCases(t *testing.T) {
 	buf, cleanup := setupTestLogger(t, InfoLevel)
 	defer cleanup()
@@ -70,12 +163,12 @@ func TestStructuredEdgeCases(t *testing.T) {
 	}{
 		{
 			"Empty fields",
-			func() { logStructured(InfoLevel, nil) },
+			func() { std.logStructured(InfoLevel, "", nil) },
 			func(output string) bool { return output == "" },
 		},
 		{
 			"Empty slice",
-			func() { logStructured(InfoLevel, []Data{}) },
+			func() { std.logStructured(InfoLevel, "", []Data{}) },
 			func(output string) bool { return output == "" },
 		},
 		{
@@ -128,14 +221,14 @@ func TestStructuredLevelFiltering(t *testing.T) {
 	defer cleanup()
 
 	// Test that lower levels are filtered
-	logStructured(DebugLevel, []Data{WithString("debug", "test")})
-	logStructured(InfoLevel, []Data{WithString("info", "test")})
+	std.logStructured(DebugLevel, "", []Data{WithString("debug", "test")})
+	std.logStructured(InfoLevel, "", []Data{WithString("info", "test")})
 	if buf.Len() > 0 {
 		t.Error("Lower levels should be filtered out")
 	}
 
 	// Test that same/higher levels pass through
-	logStructured(WarnLevel, []Data{WithString("warn", "test")})
+	std.logStructured(WarnLevel, "", []Data{WithString("warn", "test")})
 	if buf.Len() == 0 {
 		t.Error("Same level should not be filtered out")
 	}
@@ -2,7 +2,9 @@ package log
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"io"
 	"strings"
 	"sync"
 	"testing"
@@ -12,9 +14,10 @@ import (
 func setupTestLogger(t *testing.T, level Level) (buffer *bytes.Buffer, cleanup func()) {
 	t.Helper()
 	var buf bytes.Buffer
+	// A fresh default logger keeps settings changed by one test out of the others
 	old := std
-	SetOutput(&buf)
-	SetLevel(level)
+	std = newLogger(level, &buf)
+	SetExitFunc(func(int) {})
 	return &buf, func() { std = old }
 }
 
@@ -182,6 +185,7 @@ func TestLevelFiltering(t *testing.T) {
 
 func TestConfiguration(t *testing.T) {
 	old := std
+	std = newLogger(InfoLevel, io.Discard)
 	defer func() { std = old }()
 
 	t.Run("SetOutput", func(t *testing.T) {
@@ -275,6 +279,15 @@ func TestNoopLogger(t *testing.T) {
 	noop.SetLevel(InfoLevel)
 	noop.SetIncludeFileInfo(true)
 	noop.SetOutput(&bytes.Buffer{})
+	_ = noop.Sync()
+	noop.With(WithString("test", "with")).Info("test")
+	noop.Named("test").Info("test")
+	noop.AddHook(NewHook(func(*EntryView) error { return nil }))
+	noop.InfoCtx(context.Background(), "test", WithString("test", "ctx"))
+	noop.PanicCtx(context.Background(), "test")
+	noop.Log(InfoLevel, "test")
+	noop.Logf(InfoLevel, "test %s", "log")
+	noop.LogS(InfoLevel, WithString("test", "log"))
 
 	// Test all methods
 	noop.Debug("test")
@@ -295,6 +308,10 @@ func TestNoopLogger(t *testing.T) {
 	noop.Panic("test")
 	noop.Panicf("test %s", "panic")
 	noop.PanicS(WithString("test", "panic"))
+	noop.DPanic("test")
+	noop.DPanicf("test %s", "dpanic")
+	noop.DPanicS(WithString("test", "dpanic"))
+	noop.DPanicCtx(context.Background(), "test")
 }
 
 func TestEdgeCases(t *testing.T) {
@@ -0,0 +1,261 @@
+package log
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nszilard/log/internal"
+)
+
+// TimeEncoding selects how the built-in encoders write entry timestamps and
+// WithTime fields.
+type TimeEncoding uint8
+
+const (
+	// TimeLayout writes times with a layout, following the rules of
+	// time.Time.Format. It is the default.
+	TimeLayout TimeEncoding = iota
+	// TimeEpochSeconds writes times as seconds since the Unix epoch, with the
+	// fraction of the second as decimals.
+	TimeEpochSeconds
+	// TimeEpochMillis writes times as whole milliseconds since the Unix epoch.
+	TimeEpochMillis
+	// TimeEpochNanos writes times as nanoseconds since the Unix epoch.
+	TimeEpochNanos
+	// TimeOmitted leaves the timestamp out of entries, for outputs that add
+	// their own, such as journald. WithTime fields are written with their layout.
+	TimeOmitted
+)
+
+var timeEncodingNames = [...]string{"layout", "epoch_s", "epoch_ms", "epoch_ns", "omitted"}
+
+// String returns the lowercase name of the time encoding.
+func (te TimeEncoding) String() string {
+	if int(te) < len(timeEncodingNames) {
+		return timeEncodingNames[te]
+	}
+	return fmt.Sprintf("TimeEncoding(%d)", te)
+}
+
+// ParseTimeEncoding returns the time encoding with the given name, matched
+// without regard to case.
+func ParseTimeEncoding(name string) (TimeEncoding, error) {
+	for i, n := range timeEncodingNames {
+		if strings.EqualFold(strings.TrimSpace(name), n) {
+			return TimeEncoding(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown time encoding %q", name)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseTimeEncoding.
+func (te *TimeEncoding) UnmarshalText(text []byte) error {
+	encoding, err := ParseTimeEncoding(string(text))
+	if err != nil {
+		return err
+	}
+	*te = encoding
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (te TimeEncoding) MarshalText() ([]byte, error) {
+	if int(te) >= len(timeEncodingNames) {
+		return nil, fmt.Errorf("invalid time encoding %d", te)
+	}
+	return []byte(timeEncodingNames[te]), nil
+}
+
+// OptionTimeEncoding sets how entry timestamps and WithTime fields are written.
+func OptionTimeEncoding(encoding TimeEncoding) Option {
+	return func(l *logger) {
+		if l.encoderConfig != nil {
+			l.encoderConfig.TimeEncoding = encoding
+			l.buildCores()
+		}
+	}
+}
+
+// OptionTimeLocation sets the location entry timestamps and WithTime fields
+// are converted to before they are written with a layout.
+func OptionTimeLocation(loc *time.Location) Option {
+	return func(l *logger) {
+		if l.encoderConfig != nil {
+			l.encoderConfig.TimeLocation = loc
+			l.buildCores()
+		}
+	}
+}
+
+// OptionFieldTimeFormat sets the layout used to write WithTime fields.
+// The layout follows the rules of time.Time.Format.
+func OptionFieldTimeFormat(layout string) Option {
+	return func(l *logger) {
+		if l.encoderConfig != nil {
+			l.encoderConfig.FieldTimeFormat = layout
+			l.buildCores()
+		}
+	}
+}
+
+// DurationEncoding selects how the built-in encoders write WithDuration fields.
+type DurationEncoding uint8
+
+const (
+	// DurationDefault writes durations as integer nanoseconds in JSON and in
+	// the form of time.Duration.String, such as 1.5s, in the other formats.
+	DurationDefault DurationEncoding = iota
+	// DurationNanos writes durations as integer nanoseconds.
+	DurationNanos
+	// DurationMillis writes durations as milliseconds, with decimals.
+	DurationMillis
+	// DurationSeconds writes durations as seconds, with decimals.
+	DurationSeconds
+	// DurationString writes durations in the form of time.Duration.String,
+	// as strings in JSON.
+	DurationString
+)
+
+var durationEncodingNames = [...]string{"default", "nanos", "millis", "seconds", "string"}
+
+// String returns the lowercase name of the duration encoding.
+func (de DurationEncoding) String() string {
+	if int(de) < len(durationEncodingNames) {
+		return durationEncodingNames[de]
+	}
+	return fmt.Sprintf("DurationEncoding(%d)", de)
+}
+
+// ParseDurationEncoding returns the duration encoding with the given name,
+// matched without regard to case.
+func ParseDurationEncoding(name string) (DurationEncoding, error) {
+	for i, n := range durationEncodingNames {
+		if strings.EqualFold(strings.TrimSpace(name), n) {
+			return DurationEncoding(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown duration encoding %q", name)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseDurationEncoding.
+func (de *DurationEncoding) UnmarshalText(text []byte) error {
+	encoding, err := ParseDurationEncoding(string(text))
+	if err != nil {
+		return err
+	}
+	*de = encoding
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (de DurationEncoding) MarshalText() ([]byte, error) {
+	if int(de) >= len(durationEncodingNames) {
+		return nil, fmt.Errorf("invalid duration encoding %d", de)
+	}
+	return []byte(durationEncodingNames[de]), nil
+}
+
+// OptionDurationEncoding sets how WithDuration fields are written.
+func OptionDurationEncoding(encoding DurationEncoding) Option {
+	return func(l *logger) {
+		if l.encoderConfig != nil {
+			l.encoderConfig.DurationEncoding = encoding
+			l.buildCores()
+		}
+	}
+}
+
+// appendDuration appends d in the encoding, unquoted.
+func appendDuration(buf []byte, d time.Duration, encoding DurationEncoding) []byte {
+	switch encoding {
+	case DurationMillis:
+		return strconv.AppendFloat(buf, float64(d)/float64(time.Millisecond), 'f', -1, 64)
+	case DurationSeconds:
+		return strconv.AppendFloat(buf, d.Seconds(), 'f', -1, 64)
+	case DurationString:
+		return append(buf, d.String()...)
+	}
+	return strconv.AppendInt(buf, int64(d), 10)
+}
+
+// appendJSONDuration appends d in the encoding as a JSON value.
+func appendJSONDuration(buf []byte, d time.Duration, encoding DurationEncoding) []byte {
+	if encoding != DurationString {
+		return appendDuration(buf, d, encoding)
+	}
+	buf = append(buf, '"')
+	buf = appendDuration(buf, d, encoding)
+	return append(buf, '"')
+}
+
+// timeEncoder writes times in one encoding.
+type timeEncoder struct {
+	encoding TimeEncoding
+	layout   string
+	location *time.Location // Nil keeps the location of the time
+}
+
+// omitted reports whether times are left out.
+func (te *timeEncoder) omitted() bool {
+	return te.encoding == TimeOmitted
+}
+
+// appendTime appends t as a number or with the layout, unquoted.
+func (te *timeEncoder) appendTime(buf []byte, t time.Time) []byte {
+	switch te.encoding {
+	case TimeEpochSeconds:
+		return appendEpochSeconds(buf, t)
+	case TimeEpochMillis:
+		return strconv.AppendInt(buf, t.UnixMilli(), 10)
+	case TimeEpochNanos:
+		return strconv.AppendInt(buf, t.UnixNano(), 10)
+	}
+	if te.location != nil {
+		t = t.In(te.location)
+	}
+	return t.AppendFormat(buf, te.layout)
+}
+
+// appendJSON appends t as a JSON number, or as a JSON string with the layout.
+func (te *timeEncoder) appendJSON(buf []byte, t time.Time) []byte {
+	if te.encoding != TimeLayout && te.encoding != TimeOmitted {
+		return te.appendTime(buf, t)
+	}
+	var tmp [64]byte
+	buf = append(buf, '"')
+	buf = internal.AppendJSONString(buf, string(te.appendTime(tmp[:0], t)))
+	return append(buf, '"')
+}
+
+// appendLogfmt appends t as a logfmt value, quoted when the layout needs it.
+func (te *timeEncoder) appendLogfmt(buf []byte, t time.Time) []byte {
+	var tmp [64]byte
+	return internal.AppendLogfmtString(buf, string(te.appendTime(tmp[:0], t)))
+}
+
+// appendEpochSeconds appends t as seconds since the Unix epoch, with the
+// nanoseconds as decimals without trailing zeros.
+func appendEpochSeconds(buf []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	if sec < 0 && nsec > 0 {
+		// The fraction counts towards zero, away from the earlier second
+		sec, nsec = sec+1, 1e9-nsec
+		if sec == 0 {
+			buf = append(buf, '-')
+		}
+	}
+	buf = strconv.AppendInt(buf, sec, 10)
+	if nsec == 0 {
+		return buf
+	}
+	start := len(buf) + 1
+	buf = strconv.AppendInt(append(buf, '.'), 1e9+nsec, 10)
+	// Drop the leading 1 that kept the zeros of the fraction
+	buf = append(buf[:start], buf[start+1:]...)
+	for buf[len(buf)-1] == '0' {
+		buf = buf[:len(buf)-1]
+	}
+	return buf
+}
@@ -0,0 +1,192 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimeEncodings(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	event := time.Date(2025, 9, 25, 10, 0, 0, 1500, time.UTC)
+	entry := &Entry{Level: InfoLevel, Time: fixedClock(), Message: "tick", Fields: []Data{WithTime("at", event)}}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{
+			"Default",
+			NewJSONEncoder(EncoderConfig{}),
+			`{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","msg":"tick","at":"2025-09-25T10:00:00.0000015Z"}` + "\n",
+		},
+		{
+			"EpochSeconds",
+			NewJSONEncoder(EncoderConfig{TimeEncoding: TimeEpochSeconds}),
+			`{"timestamp":1758806418.524,"level":"INFO","msg":"tick","at":1758794400.0000015}` + "\n",
+		},
+		{
+			"EpochMillis",
+			NewJSONEncoder(EncoderConfig{TimeEncoding: TimeEpochMillis}),
+			`{"timestamp":1758806418524,"level":"INFO","msg":"tick","at":1758794400000}` + "\n",
+		},
+		{
+			"EpochNanos",
+			NewLogfmtEncoder(EncoderConfig{TimeEncoding: TimeEpochNanos}),
+			"ts=1758806418524000000 level=info msg=tick at=1758794400000001500\n",
+		},
+		{
+			"Location",
+			NewTextEncoder(EncoderConfig{TimeLocation: berlin, TimeFormat: time.RFC3339, FieldTimeFormat: time.Kitchen}),
+			"2025-09-25T15:20:18+02:00 [INFO] ▶ tick at=12:00PM\n",
+		},
+		{
+			"Omitted",
+			NewJSONEncoder(EncoderConfig{TimeEncoding: TimeOmitted}),
+			`{"level":"INFO","msg":"tick","at":"2025-09-25T10:00:00.0000015Z"}` + "\n",
+		},
+		{
+			"OmittedText",
+			NewTextEncoder(EncoderConfig{TimeEncoding: TimeOmitted}),
+			"[INFO] ▶ tick at=2025-09-25T10:00:00.0000015Z\n",
+		},
+		{
+			"OmittedLogfmt",
+			NewLogfmtEncoder(EncoderConfig{TimeEncoding: TimeOmitted}),
+			"level=info msg=tick at=2025-09-25T10:00:00.0000015Z\n",
+		},
+		{
+			"OmittedConsole",
+			NewConsoleEncoder(EncoderConfig{TimeEncoding: TimeOmitted}, false),
+			"INFO  ▶ tick                                     at=2025-09-25T10:00:00.0000015Z\n",
+		},
+		{
+			"QuotedLayout",
+			NewLogfmtEncoder(EncoderConfig{TimeFormat: "Jan 2 15:04"}),
+			`ts="Sep 25 13:20" level=info msg=tick at=2025-09-25T10:00:00.0000015Z` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.encoder.EncodeEntry(nil, entry)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestEpochSeconds(t *testing.T) {
+	tests := []struct {
+		t        time.Time
+		expected string
+	}{
+		{time.Unix(0, 0), "0"},
+		{time.Unix(1, 500000000), "1.5"},
+		{time.Unix(-1, 500000000), "-0.5"},
+		{time.Unix(-2, 250000000), "-1.75"},
+		{time.Unix(10, 1), "10.000000001"},
+	}
+	for _, tt := range tests {
+		if got := string(appendEpochSeconds(nil, tt.t)); got != tt.expected {
+			t.Errorf("Expected %s for %v, got %s", tt.expected, tt.t, got)
+		}
+	}
+}
+
+func TestTimeEncodingOptions(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false),
+		OptionTimeEncoding(TimeEpochMillis), OptionFieldTimeFormat(time.DateOnly))
+
+	logger.InfoS(WithTime("day", fixedClock()), WithTimePtr("none", nil))
+	if want := `{"timestamp":1758806418524,"level":"INFO","day":1758806418524,"none":null}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	logger = New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false),
+		OptionTimeLocation(time.FixedZone("EST", -5*60*60)), OptionFieldTimeFormat(time.DateOnly))
+	logger.InfoS(WithTime("day", time.Date(2025, 9, 25, 2, 0, 0, 0, time.UTC)))
+	if want := `{"timestamp":"2025-09-25T08:20:18.524-05:00","level":"INFO","day":"2025-09-24"}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	logger = New(InfoLevel, &buf, OptionTimeEncoding(TimeOmitted), OptionIncludeFileInfo(false))
+	logger.Info("under journald")
+	if want := "[INFO] ▶ under journald\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestTimeEncodingText(t *testing.T) {
+	var cfg struct {
+		Time TimeEncoding `json:"time"`
+	}
+	if err := json.Unmarshal([]byte(`{"time":"EPOCH_MS"}`), &cfg); err != nil || cfg.Time != TimeEpochMillis {
+		t.Fatalf("Expected epoch_ms, got %v (%v)", cfg.Time, err)
+	}
+	if _, err := ParseTimeEncoding("rfc3339"); err == nil {
+		t.Error("Expected an error for an unknown time encoding")
+	}
+	for encoding := TimeLayout; encoding <= TimeOmitted; encoding++ {
+		text, err := encoding.MarshalText()
+		if err != nil || string(text) != encoding.String() {
+			t.Errorf("Unexpected text for %v: %q (%v)", encoding, text, err)
+		}
+	}
+	if TimeEncoding(9).String() != "TimeEncoding(9)" {
+		t.Errorf("Unexpected name for an invalid time encoding: %s", TimeEncoding(9))
+	}
+}
+
+func TestDurationEncodings(t *testing.T) {
+	fields := []Data{WithDuration("elapsed", 245*time.Millisecond+500*time.Microsecond), WithDurations("waits", []time.Duration{time.Second})}
+
+	tests := []struct {
+		name     string
+		encoder  Encoder
+		expected string
+	}{
+		{"JSONDefault", NewJSONEncoder(EncoderConfig{}), `,"elapsed":245500000,"waits":[1000000000]`},
+		{"JSONMillis", NewJSONEncoder(EncoderConfig{DurationEncoding: DurationMillis}), `,"elapsed":245.5,"waits":[1000]`},
+		{"JSONSeconds", NewJSONEncoder(EncoderConfig{DurationEncoding: DurationSeconds}), `,"elapsed":0.2455,"waits":[1]`},
+		{"JSONString", NewJSONEncoder(EncoderConfig{DurationEncoding: DurationString}), `,"elapsed":"245.5ms","waits":["1s"]`},
+		{"TextDefault", NewTextEncoder(EncoderConfig{}), ` elapsed=245.5ms waits=["1s"]`},
+		{"TextNanos", NewTextEncoder(EncoderConfig{DurationEncoding: DurationNanos}), ` elapsed=245500000 waits=[1000000000]`},
+		{"LogfmtSeconds", NewLogfmtEncoder(EncoderConfig{DurationEncoding: DurationSeconds}), ` elapsed=0.2455 waits=[1]`},
+		{"ConsoleDefault", NewConsoleEncoder(EncoderConfig{}, false), ` elapsed=245.5ms waits=["1s"]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.encoder.EncodeFields(nil, fields)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDurationEncodingOption(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(InfoLevel, &buf, OptionClock(fixedClock), OptionIncludeFileInfo(false), OptionDurationEncoding(DurationSeconds)).
+		With(WithDuration("timeout", 2*time.Second))
+
+	logger.InfoS(WithDuration("elapsed", 1500*time.Millisecond))
+	if want := `{"timestamp":"2025-09-25T13:20:18.524Z","level":"INFO","timeout":2,"elapsed":1.5}` + "\n"; buf.String() != want {
+		t.Errorf("Expected %q, got %q", want, buf.String())
+	}
+
+	var cfg struct {
+		Durations DurationEncoding `json:"durations"`
+	}
+	if err := json.Unmarshal([]byte(`{"durations":"Millis"}`), &cfg); err != nil || cfg.Durations != DurationMillis {
+		t.Errorf("Expected millis, got %v (%v)", cfg.Durations, err)
+	}
+	if _, err := ParseDurationEncoding("hours"); err == nil {
+		t.Error("Expected an error for an unknown duration encoding")
+	}
+}
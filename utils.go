@@ -1,53 +1,157 @@
 package log
 
 import (
-	"time"
+	"context"
+	"fmt"
+	"runtime"
+	"strconv"
 	"unsafe"
 
 	"github.com/nszilard/log/internal"
 )
 
-func logStructured(level Level, fields []Data) {
-	if level <= std.currentLevel && len(fields) > 0 {
-		allTyped := true
-		for _, f := range fields {
-			if f.Type == UnknownType {
-				allTyped = false
+// enabled reports whether entries at the given level pass the logger's level filter.
+func (l *logger) enabled(level Level) bool {
+	return level <= l.level.effective()
+}
+
+// write logs a text message at the given level. Callers check enabled first.
+func (l *logger) write(level Level, msg string) {
+	cores := l.cores.Load()
+	l.writeEntry(context.Background(), cores.core, cores.coreContext, level, l.callerPC(4), msg, nil)
+}
+
+// logStructured logs fields, preceded by msg if it is not empty. Nothing is
+// written when there is neither a message nor fields.
+func (l *logger) logStructured(level Level, msg string, fields []Data) {
+	if l.enabled(level) && (len(fields) > 0 || msg != "") {
+		cores := l.cores.Load()
+		l.writeEntry(context.Background(), cores.structuredCore, cores.structuredContext, level, l.callerPC(4), msg, fields)
+	}
+}
+
+// logCtx is logStructured for the *Ctx methods. It adds the fields carried
+// by ctx, once the level is known to be enabled, and passes ctx on to the
+// slog sink.
+func (l *logger) logCtx(ctx context.Context, level Level, msg string, fields []Data) {
+	if !l.enabled(level) {
+		return
+	}
+	if fields = withContextFields(ctx, fields); len(fields) > 0 || msg != "" {
+		cores := l.cores.Load()
+		l.writeEntry(ctx, cores.structuredCore, cores.structuredContext, level, l.callerPC(4), msg, fields)
+	}
+}
+
+// writeEntry builds an entry logged at pc, or without caller if pc is zero,
+// and writes it with core, whose encoder produced bound.
+func (l *logger) writeEntry(ctx context.Context, core *Core, bound []byte, level Level, pc uintptr, msg string, fields []Data) {
+	e := getEntry()
+	e.Level, e.Time, e.Message, e.Fields = level, l.now(), msg, fields
+	if pc != 0 {
+		e.File, e.Line = internal.CallerFromPC(pc)
+	}
+	if l.stackLevel != nil && l.stackLevel.Enabled(level) {
+		// Skip write or logStructured and the logging method
+		e.Stack = stacktrace(3, 0)
+	}
+	l.dispatch(ctx, core, bound, e, pc)
+	putEntry(e)
+}
+
+// dispatch runs the hooks for e and then forwards it to the slog sink, if
+// the logger has one, or writes it with core.
+func (l *logger) dispatch(ctx context.Context, core *Core, bound []byte, e *Entry, pc uintptr) {
+	e.LoggerName = l.name
+	e.Fields = l.resolveLazy(e.Fields)
+	if hooks := l.hooks.Load(); hooks != nil && len(hooks[e.Level]) > 0 {
+		view := &EntryView{entry: e, context: l.context[:len(l.context)-len(l.lazyContext)]}
+		l.fire(hooks[e.Level], view)
+		if len(view.added) > 0 {
+			e.Fields = append(e.Fields[:len(e.Fields):len(e.Fields)], view.added...)
+		}
+	}
+
+	if l.slogSink != nil {
+		l.forward(ctx, e, pc)
+		return
+	}
+	e.Context = bound
+	if err := core.Write(e); err != nil {
+		fmt.Fprintf(l.errorOutput, "log: write failed: %v\n", err)
+	}
+}
+
+// allTyped reports whether none of the fields needs the untyped encoding path.
+func allTyped(fields []Data) bool {
+	for _, f := range fields {
+		if f.Type == UnknownType {
+			return false
+		}
+	}
+	return true
+}
+
+// toInternal reinterprets fields as internal fields; both types share the same memory layout.
+func toInternal(fields []Data) []internal.Data {
+	return *(*[]internal.Data)(unsafe.Pointer(&fields))
+}
+
+// toInternalField reinterprets a field as an internal field.
+func toInternalField(field *Data) *internal.Data {
+	return (*internal.Data)(unsafe.Pointer(field))
+}
+
+// fromInternalField reinterprets an internal field as a field.
+func fromInternalField(field *internal.Data) *Data {
+	return (*Data)(unsafe.Pointer(field))
+}
+
+// stacktrace formats the stack of the calling goroutine, one function and
+// its file:line per frame. It starts skip frames above the caller of
+// stacktrace or, if pc is not zero, at the frame of pc.
+func stacktrace(skip int, pc uintptr) string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(skip+2, pcs)
+	for n == len(pcs) {
+		pcs = make([]uintptr, len(pcs)*2)
+		n = runtime.Callers(skip+2, pcs)
+	}
+	pcs = pcs[:n]
+	if pc != 0 {
+		for i := range pcs {
+			if pcs[i] == pc {
+				pcs = pcs[i:]
 				break
 			}
 		}
+	}
 
-		if allTyped {
-			std.internal.LogStructuredTypedWithFileInfo(level.String(), std.includeFileInfo, *(*[]internal.Data)(unsafe.Pointer(&fields)))
-		} else {
-			kv := make([]any, 0, len(fields)*2)
-			for _, f := range fields {
-				kv = append(kv, f.Key)
-
-				switch f.Type {
-				case StringType:
-					kv = append(kv, f.String)
-				case IntType:
-					kv = append(kv, f.Integer)
-				case FloatType:
-					kv = append(kv, f.Float)
-				case BoolType:
-					kv = append(kv, f.Bool)
-				case ErrorType:
-					if f.Interface == nil {
-						kv = append(kv, (*error)(nil))
-					} else {
-						kv = append(kv, f.String)
-					}
-				case DurationType:
-					kv = append(kv, time.Duration(f.Integer))
-				case TimeType:
-					kv = append(kv, f.Interface)
-				default:
-					kv = append(kv, f.Interface)
-				}
-			}
-			std.internal.LogStructuredWithFileInfo(level.String(), std.includeFileInfo, kv...)
+	var buf []byte
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if len(buf) > 0 {
+			buf = append(buf, '\n')
 		}
+		buf = append(buf, frame.Function...)
+		buf = append(buf, "\n\t"...)
+		buf = append(buf, frame.File...)
+		buf = append(buf, ':')
+		buf = strconv.AppendInt(buf, int64(frame.Line), 10)
+		if !more {
+			return string(buf)
+		}
+	}
+}
+
+// callerPC returns the program counter of the caller skip frames up, counting
+// callerPC itself, or zero if the logger does not include file information.
+func (l *logger) callerPC(skip int) uintptr {
+	if !l.includeFileInfo.Load() {
+		return 0
 	}
+	var pcs [1]uintptr
+	runtime.Callers(skip, pcs[:])
+	return pcs[0]
 }